@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+)
+
+// watch calls fn with the index of each Future once it settles,
+// until out is settled itself.
+func watch[T, U any](out *Future[U], fs []*Future[T], fn func(i int)) {
+	for i, f := range fs {
+		go func() {
+			select {
+			case <-f.done:
+				fn(i)
+			case <-out.done:
+			}
+		}()
+	}
+}
+
+// All returns a Future of the values of all the Futures, in order.
+// It fails as soon as any of them fails, with its error.
+//
+//	vs, err := async.All(ctx, f1, f2, f3).Await(ctx)
+func All[T any](ctx context.Context, fs ...*Future[T]) *Future[[]T] {
+	out := newFuture[[]T](ctx)
+	values := make([]T, len(fs))
+	var lock sync.Mutex
+	left := len(fs)
+	if left == 0 {
+		out.settle(functional.Ok(values))
+		return out
+	}
+	watch(out, fs, func(i int) {
+		v, err := fs[i].result.Get()
+		if err != nil {
+			out.settle(functional.Err[[]T](err))
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		values[i] = v
+		if left--; left == 0 {
+			out.settle(functional.Ok(values))
+		}
+	})
+	return out
+}
+
+// AllSettled returns a Future of the results of all the Futures, in order,
+// once every one of them is settled. It only fails if ctx is done first.
+func AllSettled[T any](ctx context.Context, fs ...*Future[T]) *Future[[]functional.Result[T]] {
+	out := newFuture[[]functional.Result[T]](ctx)
+	results := make([]functional.Result[T], len(fs))
+	var lock sync.Mutex
+	left := len(fs)
+	if left == 0 {
+		out.settle(functional.Ok(results))
+		return out
+	}
+	watch(out, fs, func(i int) {
+		lock.Lock()
+		defer lock.Unlock()
+		results[i] = fs[i].result
+		if left--; left == 0 {
+			out.settle(functional.Ok(results))
+		}
+	})
+	return out
+}
+
+// Any returns a Future of the value of the first Future to succeed.
+// If all of them fail, it fails with all their errors joined,
+// with no Futures it fails with ErrNoFutures.
+func Any[T any](ctx context.Context, fs ...*Future[T]) *Future[T] {
+	out := newFuture[T](ctx)
+	errs := make([]error, len(fs))
+	var lock sync.Mutex
+	left := len(fs)
+	if left == 0 {
+		out.settle(functional.Err[T](ErrNoFutures))
+		return out
+	}
+	watch(out, fs, func(i int) {
+		v, err := fs[i].result.Get()
+		if err == nil {
+			out.settle(functional.Ok(v))
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		errs[i] = err
+		if left--; left == 0 {
+			out.settle(functional.Err[T](errors.Join(errs...)))
+		}
+	})
+	return out
+}
+
+// Race returns a Future settled like the first of the Futures to settle.
+// With no Futures, it stays pending until ctx is done.
+func Race[T any](ctx context.Context, fs ...*Future[T]) *Future[T] {
+	out := newFuture[T](ctx)
+	watch(out, fs, func(i int) {
+		out.settle(fs[i].result)
+	})
+	return out
+}
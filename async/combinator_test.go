@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package async_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/async"
+	"github.com/mizumoto-cn/fpkit/functional"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	ctx := context.Background()
+	vs, err := async.All(ctx,
+		async.Go(ctx, sleep(20*time.Millisecond, 1, nil)),
+		async.Go(ctx, sleep(0, 2, nil)),
+		async.Resolved(3),
+	).Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, vs)
+
+	// fails fast
+	slow := async.Go(ctx, sleep(time.Second, 1, nil))
+	defer slow.Cancel()
+	start := time.Now()
+	_, err = async.All(ctx, slow, async.Rejected[int](errBoom)).Await(ctx)
+	assert.ErrorIs(t, err, errBoom)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	vs, err = async.All[int](ctx).Await(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, vs)
+}
+
+func TestAllSettled(t *testing.T) {
+	ctx := context.Background()
+	rs, err := async.AllSettled(ctx,
+		async.Go(ctx, sleep(10*time.Millisecond, 1, nil)),
+		async.Rejected[int](errBoom),
+	).Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, functional.Ok(1), rs[0])
+	assert.ErrorIs(t, rs[1].Err(), errBoom)
+
+	rs, err = async.AllSettled[int](ctx).Await(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, rs)
+}
+
+func TestAny(t *testing.T) {
+	ctx := context.Background()
+	v, err := async.Any(ctx,
+		async.Rejected[int](errBoom),
+		async.Go(ctx, sleep(10*time.Millisecond, 2, nil)),
+	).Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+
+	_, err = async.Any(ctx,
+		async.Rejected[int](errBoom),
+		async.Rejected[int](context.Canceled),
+	).Await(ctx)
+	assert.ErrorIs(t, err, errBoom)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = async.Any[int](ctx).Await(ctx)
+	assert.ErrorIs(t, err, async.ErrNoFutures)
+}
+
+func TestRace(t *testing.T) {
+	ctx := context.Background()
+	slow := async.Go(ctx, sleep(time.Second, 1, nil))
+	defer slow.Cancel()
+	v, err := async.Race(ctx, slow, async.Go(ctx, sleep(5*time.Millisecond, 2, nil))).Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+
+	_, err = async.Race(ctx, slow, async.Rejected[int](errBoom)).Await(ctx)
+	assert.ErrorIs(t, err, errBoom)
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = async.Race[int](timeout).Await(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCombinatorCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := async.Go(context.Background(), sleep(time.Second, 1, nil))
+	defer f.Cancel()
+	all := async.All(ctx, f)
+	cancel()
+	_, err := all.Await(context.Background())
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+)
+
+// ErrNoFutures is the error Any fails with when it is given no Futures.
+var ErrNoFutures = errors.New("fpkit: no futures")
+
+// PanicError is the error a Future fails with when its function panics.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("fpkit: future panicked: %v", e.Value)
+}
+
+// errSettled is the cause the context of a Future is cancelled with once it is settled.
+var errSettled = errors.New("fpkit: future settled")
+
+// Future is the eventual result of an asynchronous computation.
+// Each Future has its own context, derived from the one it was created with:
+// cancelling it, or any of its ancestors, cancels the Future and all the Futures chained after it.
+// The context is released as soon as the Future settles.
+type Future[T any] struct {
+	parent context.Context
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	stop   func()
+
+	once   sync.Once
+	done   chan struct{}
+	result functional.Result[T]
+}
+
+// newFuture creates a pending Future that fails when ctx is done.
+func newFuture[T any](ctx context.Context) *Future[T] {
+	return newChained[T](ctx, nil)
+}
+
+// newChained creates a pending Future that fails when ctx is done,
+// or when up, the context of the Future it is chained after, is cancelled before that Future settles.
+func newChained[T any](parent, up context.Context) *Future[T] {
+	ctx, cancel := context.WithCancelCause(parent)
+	f := &Future[T]{parent: parent, ctx: ctx, cancel: cancel, done: make(chan struct{})}
+	stopUp := func() bool { return false }
+	if up != nil {
+		stopUp = context.AfterFunc(up, func() {
+			if cause := context.Cause(up); cause != errSettled {
+				cancel(cause)
+			}
+		})
+	}
+	stopCtx := context.AfterFunc(ctx, func() {
+		f.once.Do(func() {
+			f.result = functional.Err[T](context.Cause(ctx))
+			close(f.done)
+		})
+		stopUp()
+	})
+	f.stop = func() {
+		stopCtx()
+		stopUp()
+	}
+	return f
+}
+
+// settle completes the Future with the result, the first call wins.
+// It releases the context of the Future, so it no longer hangs on its parent,
+// before closing done, so that a Cancel once settled cannot cancel it with another cause.
+func (f *Future[T]) settle(r functional.Result[T]) bool {
+	settled := false
+	f.once.Do(func() {
+		f.result = r
+		f.stop()
+		f.cancel(errSettled)
+		close(f.done)
+		settled = true
+	})
+	return settled
+}
+
+// run calls fn and settles the Future with its outcome, recovering panics into a PanicError.
+func (f *Future[T]) run(fn func(context.Context) (T, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			f.settle(functional.Err[T](&PanicError{Value: r, Stack: debug.Stack()}))
+		}
+	}()
+	f.settle(functional.ResultOf(fn(f.ctx)))
+}
+
+// Go runs fn in a new goroutine and returns the Future of its result.
+// fn receives the context of the Future and should return early when it is done.
+//
+//	f := async.Go(ctx, func(ctx context.Context) (int, error) {
+//		return fetch(ctx)
+//	})
+//	v, err := f.Await(ctx)
+func Go[T any](ctx context.Context, fn func(context.Context) (T, error)) *Future[T] {
+	f := newFuture[T](ctx)
+	go f.run(fn)
+	return f
+}
+
+// Await blocks until the Future is settled or ctx is done, and returns the result.
+// When ctx is done first, it returns ctx.Err() and leaves the Future running.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.result.Get()
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Done returns a channel that is closed when the Future is settled.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Result returns the result of the Future and true if it is settled,
+// otherwise it returns false without blocking.
+func (f *Future[T]) Result() (functional.Result[T], bool) {
+	select {
+	case <-f.done:
+		return f.result, true
+	default:
+		return functional.Result[T]{}, false
+	}
+}
+
+// Context returns the context of the Future. It is done once the Future is settled.
+func (f *Future[T]) Context() context.Context {
+	return f.ctx
+}
+
+// Cancel cancels the context of the Future.
+// A pending Future fails with context.Canceled, and so do the Futures chained after it.
+// Cancelling a settled Future has no effect.
+func (f *Future[T]) Cancel() {
+	f.cancel(nil)
+}
+
+// Then chains an asynchronous computation after the Future.
+// fn runs with the value once the Future succeeds, if it fails the error is passed on.
+// The new Future is derived from the context f was created with, and cancelling f before it settles cancels it too.
+//
+//	user := async.Go(ctx, fetchUser)
+//	orders := async.Then(user, func(ctx context.Context, u User) ([]Order, error) {
+//		return fetchOrders(ctx, u.ID)
+//	})
+func Then[T, U any](f *Future[T], fn func(context.Context, T) (U, error)) *Future[U] {
+	next := newChained[U](f.parent, f.ctx)
+	go func() {
+		select {
+		case <-f.done:
+		case <-next.done:
+			return
+		}
+		v, err := f.result.Get()
+		if err != nil {
+			next.settle(functional.Err[U](err))
+			return
+		}
+		next.run(func(ctx context.Context) (U, error) {
+			return fn(ctx, v)
+		})
+	}()
+	return next
+}
+
+// Map applies the function to the value of the Future once it succeeds.
+func Map[T, U any](f *Future[T], fn func(T) U) *Future[U] {
+	return Then(f, func(_ context.Context, v T) (U, error) {
+		return fn(v), nil
+	})
+}
+
+// Timeout returns a Future that settles like f, or fails with context.DeadlineExceeded
+// if f is not settled within d. It does not cancel f.
+func Timeout[T any](f *Future[T], d time.Duration) *Future[T] {
+	ctx, cancel := context.WithTimeout(f.parent, d)
+	next := newChained[T](ctx, f.ctx)
+	go func() {
+		defer cancel()
+		select {
+		case <-f.done:
+			next.settle(f.result)
+		case <-next.done:
+		}
+	}()
+	return next
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package async_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/async"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errBoom = errors.New("boom")
+
+// sleep returns a function that waits for d, or until the context is done.
+func sleep[T any](d time.Duration, v T, err error) func(context.Context) (T, error) {
+	return func(ctx context.Context) (T, error) {
+		select {
+		case <-time.After(d):
+			return v, err
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+func TestGo(t *testing.T) {
+	ctx := context.Background()
+	f := async.Go(ctx, sleep(10*time.Millisecond, 42, nil))
+	_, settled := f.Result()
+	assert.False(t, settled)
+
+	v, err := f.Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, v)
+	<-f.Done()
+	r, settled := f.Result()
+	assert.True(t, settled)
+	assert.Equal(t, 42, r.Value())
+
+	_, err = async.Go(ctx, sleep(0, 0, errBoom)).Await(ctx)
+	assert.ErrorIs(t, err, errBoom)
+}
+
+func TestAwaitContext(t *testing.T) {
+	f := async.Go(context.Background(), sleep(time.Second, 1, nil))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := f.Await(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	// the Future itself keeps running
+	_, settled := f.Result()
+	assert.False(t, settled)
+	f.Cancel()
+}
+
+func TestPanic(t *testing.T) {
+	f := async.Go(context.Background(), func(context.Context) (int, error) {
+		panic("oops")
+	})
+	_, err := f.Await(context.Background())
+	var pe *async.PanicError
+	assert.ErrorAs(t, err, &pe)
+	assert.Equal(t, "oops", pe.Value)
+	assert.NotEmpty(t, pe.Stack)
+	assert.Contains(t, err.Error(), "oops")
+
+	m := async.Map(async.Resolved(1), func(int) int { panic("map") })
+	_, err = m.Await(context.Background())
+	assert.ErrorAs(t, err, &pe)
+}
+
+func TestThen(t *testing.T) {
+	ctx := context.Background()
+	f := async.Go(ctx, sleep(5*time.Millisecond, 21, nil))
+	doubled := async.Map(f, func(x int) int { return x * 2 })
+	str := async.Then(doubled, func(_ context.Context, x int) (string, error) {
+		return strconv.Itoa(x), nil
+	})
+	v, err := str.Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "42", v)
+
+	// errors skip the chained functions
+	called := false
+	failed := async.Map(async.Rejected[int](errBoom), func(x int) int {
+		called = true
+		return x
+	})
+	_, err = failed.Await(ctx)
+	assert.ErrorIs(t, err, errBoom)
+	assert.False(t, called)
+}
+
+func TestCancelPropagation(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	f := async.Go(parent, sleep(time.Second, 1, nil))
+	g := async.Then(f, sleep2[int](time.Second))
+	h := async.Map(g, func(x int) int { return x })
+	cancel()
+
+	for _, fut := range []*async.Future[int]{f, g, h} {
+		_, err := fut.Await(context.Background())
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+
+	// cancelling a Future cancels the ones chained after it, not the ones before
+	a := async.Go(context.Background(), sleep(20*time.Millisecond, 1, nil))
+	b := async.Then(a, sleep2[int](time.Second))
+	c := async.Map(b, func(x int) int { return x })
+	b.Cancel()
+	_, err := c.Await(context.Background())
+	assert.ErrorIs(t, err, context.Canceled)
+	v, err := a.Await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+}
+
+func TestSettleReleasesContext(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f := async.Go(parent, sleep(0, 1, nil))
+	g := async.Then(f, sleep2[int](10*time.Millisecond))
+	timed := async.Timeout(f, time.Second)
+
+	_, err := f.Await(context.Background())
+	assert.NoError(t, err)
+	// the context of a settled Future is released, so it no longer hangs on parent
+	<-f.Context().Done()
+	assert.NoError(t, parent.Err())
+
+	// and the Futures chained after it still run to completion
+	v, err := g.Await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	v, err = timed.Await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	<-g.Context().Done()
+	<-timed.Context().Done()
+}
+
+func TestCancelSettled(t *testing.T) {
+	for range 1000 {
+		p := async.NewPromise[int](context.Background())
+		f := p.Future()
+		go p.Resolve(1)
+		<-f.Done()
+		// right after settling, the Future ignores the Cancel
+		f.Cancel()
+		assert.NotErrorIs(t, context.Cause(f.Context()), context.Canceled)
+	}
+}
+
+// sleep2 returns a Then function that waits for d before passing the value on.
+func sleep2[T any](d time.Duration) func(context.Context, T) (T, error) {
+	return func(ctx context.Context, v T) (T, error) {
+		return sleep(d, v, nil)(ctx)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	slow := async.Go(context.Background(), sleep(time.Second, 1, nil))
+	defer slow.Cancel()
+	_, err := async.Timeout(slow, 10*time.Millisecond).Await(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	fast := async.Go(context.Background(), sleep(0, 2, nil))
+	v, err := async.Timeout(fast, time.Second).Await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+
+	// Go with a deadline works the same way
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = async.Go(ctx, func(context.Context) (int, error) {
+		time.Sleep(50 * time.Millisecond)
+		return 3, nil
+	}).Await(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
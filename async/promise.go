@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package async
+
+import (
+	"context"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+)
+
+// Promise is the writing side of a Future, settled by hand rather than by a function.
+//
+//	p := async.NewPromise[int](ctx)
+//	go func() { p.Resolve(42) }()
+//	v, _ := p.Future().Await(ctx) // 42
+type Promise[T any] struct {
+	f *Future[T]
+}
+
+// NewPromise creates a pending Promise.
+// Its Future fails if ctx is done before the Promise is settled.
+func NewPromise[T any](ctx context.Context) *Promise[T] {
+	return &Promise[T]{f: newFuture[T](ctx)}
+}
+
+// Future returns the Future settled by the Promise.
+func (p *Promise[T]) Future() *Future[T] {
+	return p.f
+}
+
+// Resolve settles the Promise with the value.
+// It returns false if the Promise was already settled.
+func (p *Promise[T]) Resolve(v T) bool {
+	return p.f.settle(functional.Ok(v))
+}
+
+// Reject settles the Promise with the error.
+// It returns false if the Promise was already settled.
+func (p *Promise[T]) Reject(err error) bool {
+	return p.f.settle(functional.Err[T](err))
+}
+
+// Resolved returns a Future that already succeeded with the value.
+func Resolved[T any](v T) *Future[T] {
+	p := NewPromise[T](context.Background())
+	p.Resolve(v)
+	return p.f
+}
+
+// Rejected returns a Future that already failed with the error.
+func Rejected[T any](err error) *Future[T] {
+	p := NewPromise[T](context.Background())
+	p.Reject(err)
+	return p.f
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/async"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromise(t *testing.T) {
+	p := async.NewPromise[int](context.Background())
+	go func() {
+		assert.True(t, p.Resolve(42))
+	}()
+	v, err := p.Future().Await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 42, v)
+
+	// only the first settlement counts
+	assert.False(t, p.Resolve(1))
+	assert.False(t, p.Reject(errBoom))
+	v, _ = p.Future().Await(context.Background())
+	assert.Equal(t, 42, v)
+
+	p = async.NewPromise[int](context.Background())
+	assert.True(t, p.Reject(errBoom))
+	_, err = p.Future().Await(context.Background())
+	assert.ErrorIs(t, err, errBoom)
+}
+
+func TestPromiseContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := async.NewPromise[string](ctx)
+	cancel()
+	_, err := p.Future().Await(context.Background())
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, p.Resolve("late"))
+}
+
+func TestResolvedRejected(t *testing.T) {
+	r, settled := async.Resolved("done").Result()
+	assert.True(t, settled)
+	assert.Equal(t, "done", r.Value())
+
+	r, settled = async.Rejected[string](errBoom).Result()
+	assert.True(t, settled)
+	assert.ErrorIs(t, r.Err(), errBoom)
+}
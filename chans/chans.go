@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package chans provides generic stages to connect goroutines with channels.
+//
+// Every stage runs in its own goroutine, and closes its output channels when its input is closed
+// or its context is done. So cancelling the context shuts down a whole pipeline cleanly,
+// even if nobody reads its output anymore.
+//
+//	ctx, cancel := context.WithCancel(ctx)
+//	defer cancel()
+//	lines := chans.FilterChan(ctx, in, nonEmpty)
+//	for batch := range chans.Batch(ctx, lines, 100, time.Second) {
+//		ship(batch)
+//	}
+package chans
+
+import "context"
+
+// send sends the value, and returns false if the context is done first.
+func send[T any](ctx context.Context, out chan<- T, v T) bool {
+	select {
+	case out <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// receive receives a value, and returns false if the channel is closed or the context is done first.
+func receive[T any](ctx context.Context, in <-chan T) (T, bool) {
+	select {
+	case v, ok := <-in:
+		return v, ok
+	case <-ctx.Done():
+		var zero T
+		return zero, false
+	}
+}
+
+// OrDone returns a channel of the values of in, closed when in is closed or the context is done,
+// to range over a channel without leaking when the context ends.
+//
+//	for v := range chans.OrDone(ctx, in) { ... }
+func OrDone[T any](ctx context.Context, in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			v, ok := receive(ctx, in)
+			if !ok || !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Bridge returns a channel of the values of the channels received from chs, one channel after the other.
+func Bridge[T any](ctx context.Context, chs <-chan <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			in, ok := receive(ctx, chs)
+			if !ok {
+				return
+			}
+			for {
+				v, ok := receive(ctx, in)
+				if !ok {
+					break
+				}
+				if !send(ctx, out, v) {
+					return
+				}
+			}
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Drain receives and discards the values of in until it is closed or the context is done,
+// so that the goroutine sending them can finish. It returns the number of values discarded.
+func Drain[T any](ctx context.Context, in <-chan T) int {
+	n := 0
+	for {
+		if _, ok := receive(ctx, in); !ok {
+			return n
+		}
+		n++
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package chans_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/chans"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// noLeaks fails the test if it leaves more goroutines running than it started with.
+func noLeaks(t *testing.T) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		if after := runtime.NumGoroutine(); after > before {
+			buf := make([]byte, 1<<16)
+			t.Errorf("leaked %d goroutines:\n%s", after-before, buf[:runtime.Stack(buf, true)])
+		}
+	})
+}
+
+// source returns a closed channel buffering the values.
+func source[T any](values ...T) <-chan T {
+	ch := make(chan T, len(values))
+	for _, v := range values {
+		ch <- v
+	}
+	close(ch)
+	return ch
+}
+
+// collect receives the values of the channel until it is closed.
+func collect[T any](ch <-chan T) []T {
+	var result []T
+	for v := range ch {
+		result = append(result, v)
+	}
+	return result
+}
+
+func TestOrDone(t *testing.T) {
+	noLeaks(t)
+	ctx := context.Background()
+	assert.Equal(t, []int{1, 2, 3}, collect(chans.OrDone(ctx, source(1, 2, 3))))
+
+	// a context ending while nobody sends
+	ctx, cancel := context.WithCancel(ctx)
+	never := make(chan int)
+	out := chans.OrDone(ctx, never)
+	cancel()
+	assert.Empty(t, collect(out))
+}
+
+func TestBridge(t *testing.T) {
+	noLeaks(t)
+	ctx := context.Background()
+	chs := make(chan (<-chan int))
+	go func() {
+		defer close(chs)
+		chs <- source(1, 2)
+		chs <- source[int]()
+		chs <- source(3)
+	}()
+	assert.Equal(t, []int{1, 2, 3}, collect(chans.Bridge(ctx, chs)))
+
+	// cancelling while the inner channel is pending
+	ctx, cancel := context.WithCancel(ctx)
+	pending := make(chan (<-chan int), 1)
+	pending <- make(chan int)
+	out := chans.Bridge(ctx, pending)
+	cancel()
+	assert.Empty(t, collect(out))
+}
+
+func TestDrain(t *testing.T) {
+	noLeaks(t)
+	assert.Equal(t, 3, chans.Drain(context.Background(), source(1, 2, 3)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, 0, chans.Drain(ctx, make(chan int)))
+}
+
+func TestCancelledPipeline(t *testing.T) {
+	noLeaks(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	infinite := make(chan int)
+	go func() {
+		defer close(infinite)
+		for i := 0; ; i++ {
+			select {
+			case infinite <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	squares := chans.MapChan(ctx, infinite, func(x int) int { return x * x })
+	evens := chans.FilterChan(ctx, squares, func(x int) bool { return x%2 == 0 })
+	a, b := chans.Tee(ctx, evens)
+	merged := chans.Merge(ctx, chans.Distribute(ctx, a, 3)...)
+	batches := chans.Batch(ctx, merged, 4, time.Millisecond)
+	outs := chans.FanOut(ctx, b, 2)
+
+	// read a little, then walk away
+	<-batches
+	<-outs[0]
+	cancel()
+}
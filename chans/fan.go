@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package chans
+
+import (
+	"context"
+	"sync"
+)
+
+// Merge returns a channel of the values of all the channels, in no specified order (fan-in).
+// It is closed when all of them are closed, or the context is done.
+func Merge[T any](ctx context.Context, ins ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(ins))
+	for _, in := range ins {
+		go func(in <-chan T) {
+			defer wg.Done()
+			for {
+				v, ok := receive(ctx, in)
+				if !ok || !send(ctx, out, v) {
+					return
+				}
+			}
+		}(in)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// FanOut returns n channels sharing the values of in: each value goes to one of them,
+// whichever is ready first, so that slow consumers get fewer values.
+// It panics if n is not positive.
+func FanOut[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	if n <= 0 {
+		panic("chans: FanOut to a non-positive number of channels")
+	}
+	out := make(chan T)
+	outs := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = out
+	}
+	go func() {
+		defer close(out)
+		for {
+			v, ok := receive(ctx, in)
+			if !ok || !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return outs
+}
+
+// Distribute returns n channels receiving the values of in in turn (round-robin):
+// the value i goes to the channel i % n, even if another one is ready first.
+// It panics if n is not positive.
+func Distribute[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	if n <= 0 {
+		panic("chans: Distribute to a non-positive number of channels")
+	}
+	chs := make([]chan T, n)
+	outs := make([]<-chan T, n)
+	for i := range chs {
+		chs[i] = make(chan T)
+		outs[i] = chs[i]
+	}
+	go func() {
+		defer func() {
+			for _, ch := range chs {
+				close(ch)
+			}
+		}()
+		for i := 0; ; i = (i + 1) % n {
+			v, ok := receive(ctx, in)
+			if !ok || !send(ctx, chs[i], v) {
+				return
+			}
+		}
+	}()
+	return outs
+}
+
+// Tee returns two channels both receiving every value of in.
+// A value is received from in only once both channels have received the previous one,
+// so the slower consumer sets the pace.
+func Tee[T any](ctx context.Context, in <-chan T) (<-chan T, <-chan T) {
+	out1, out2 := make(chan T), make(chan T)
+	go func() {
+		defer close(out1)
+		defer close(out2)
+		for {
+			v, ok := receive(ctx, in)
+			if !ok {
+				return
+			}
+			// send to both in any order, disabling each channel once it has received
+			o1, o2 := out1, out2
+			for o1 != nil || o2 != nil {
+				select {
+				case o1 <- v:
+					o1 = nil
+				case o2 <- v:
+					o2 = nil
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out1, out2
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package chans_test
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/chans"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	noLeaks(t)
+	ctx := context.Background()
+	got := collect(chans.Merge(ctx, source(1, 2), source(3), source[int](), source(4, 5)))
+	sort.Ints(got)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, got)
+	assert.Empty(t, collect(chans.Merge[int](ctx)))
+
+	ctx, cancel := context.WithCancel(ctx)
+	out := chans.Merge(ctx, make(chan int), make(chan int))
+	cancel()
+	assert.Empty(t, collect(out))
+}
+
+// consume collects every channel concurrently.
+func consume(outs []<-chan int) [][]int {
+	result := make([][]int, len(outs))
+	var wg sync.WaitGroup
+	for i, out := range outs {
+		wg.Add(1)
+		go func(i int, out <-chan int) {
+			defer wg.Done()
+			result[i] = collect(out)
+		}(i, out)
+	}
+	wg.Wait()
+	return result
+}
+
+func TestFanOut(t *testing.T) {
+	noLeaks(t)
+	values := make([]int, 100)
+	for i := range values {
+		values[i] = i
+	}
+	parts := consume(chans.FanOut(context.Background(), source(values...), 4))
+	assert.Len(t, parts, 4)
+	var all []int
+	for _, p := range parts {
+		all = append(all, p...)
+	}
+	sort.Ints(all)
+	assert.Equal(t, values, all)
+	assert.Panics(t, func() { chans.FanOut(context.Background(), source[int](), 0) })
+}
+
+func TestDistribute(t *testing.T) {
+	noLeaks(t)
+	parts := consume(chans.Distribute(context.Background(), source(0, 1, 2, 3, 4, 5, 6), 3))
+	assert.Equal(t, [][]int{{0, 3, 6}, {1, 4}, {2, 5}}, parts)
+	assert.Panics(t, func() { chans.Distribute(context.Background(), source[int](), -1) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	outs := chans.Distribute(ctx, source(1, 2, 3), 2)
+	<-outs[0]
+	cancel()
+	consume(outs)
+}
+
+func TestTee(t *testing.T) {
+	noLeaks(t)
+	a, b := chans.Tee(context.Background(), source(1, 2, 3))
+	parts := consume([]<-chan int{a, b})
+	assert.Equal(t, [][]int{{1, 2, 3}, {1, 2, 3}}, parts)
+
+	// only one side is read
+	ctx, cancel := context.WithCancel(context.Background())
+	a, _ = chans.Tee(ctx, source(1, 2, 3))
+	assert.Equal(t, 1, <-a)
+	cancel()
+}
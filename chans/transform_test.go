@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package chans_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/chans"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapFilterChan(t *testing.T) {
+	noLeaks(t)
+	ctx := context.Background()
+	doubled := chans.MapChan(ctx, source(1, 2, 3), func(x int) int { return x * 2 })
+	assert.Equal(t, []int{2, 4, 6}, collect(doubled))
+	odds := chans.FilterChan(ctx, source(1, 2, 3, 4, 5), func(x int) bool { return x%2 == 1 })
+	assert.Equal(t, []int{1, 3, 5}, collect(odds))
+
+	// nobody reads the output
+	ctx, cancel := context.WithCancel(ctx)
+	chans.MapChan(ctx, source(1, 2, 3), func(x int) int { return x })
+	chans.FilterChan(ctx, source(1, 2, 3), func(x int) bool { return true })
+	cancel()
+}
+
+func TestBatch(t *testing.T) {
+	noLeaks(t)
+	ctx := context.Background()
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, collect(chans.Batch(ctx, source(1, 2, 3, 4, 5), 2, 0)))
+	assert.Empty(t, collect(chans.Batch(ctx, source[int](), 2, time.Second)))
+	assert.Panics(t, func() { chans.Batch(ctx, source[int](), 0, 0) })
+
+	// a partial batch is sent after maxWait
+	in := make(chan int)
+	out := chans.Batch(ctx, in, 10, 20*time.Millisecond)
+	start := time.Now()
+	in <- 1
+	in <- 2
+	assert.Equal(t, []int{1, 2}, <-out)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	// the timer restarts with the next batch
+	in <- 3
+	assert.Equal(t, []int{3}, <-out)
+	close(in)
+	_, ok := <-out
+	assert.False(t, ok)
+
+	// cancelling with a pending batch
+	ctx, cancel := context.WithCancel(ctx)
+	in = make(chan int)
+	out = chans.Batch(ctx, in, 10, time.Hour)
+	in <- 1
+	cancel()
+	assert.Empty(t, collect(out))
+}
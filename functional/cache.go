@@ -0,0 +1,441 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package functional
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/internal/err"
+)
+
+// Cache is a generic, thread-safe key-value store used by Memoize.
+// Implementations decide which entries to keep and which to evict.
+type Cache[K comparable, V any] interface {
+	// Get returns the cached value and true if the key is present.
+	Get(K) (V, bool)
+	// Set stores the value for the key, evicting other entries if necessary.
+	Set(K, V)
+	// Len returns the number of entries currently held.
+	Len() int
+	// Stats returns a snapshot of the hit, miss and eviction counters.
+	Stats() CacheStats
+}
+
+// peeker is implemented by the caches of this package,
+// which can look up a key without counting it in their stats.
+type peeker[K comparable, V any] interface {
+	peek(K) (V, bool)
+}
+
+// CacheStats is a snapshot of the counters of a Cache.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// cacheCounter keeps the counters behind CacheStats.
+type cacheCounter struct {
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+// record counts a lookup as a hit or a miss.
+func (c *cacheCounter) record(hit bool) {
+	if hit {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+}
+
+// snapshot returns the current counters.
+func (c *cacheCounter) snapshot() CacheStats {
+	return CacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+	}
+}
+
+// MapCache is an unbounded cache backed by a map. It never evicts.
+type MapCache[K comparable, V any] struct {
+	lock  sync.RWMutex
+	items map[K]V
+	stats cacheCounter
+}
+
+var _ Cache[int, int] = (*MapCache[int, int])(nil)
+
+// NewMapCache creates a new unbounded MapCache.
+func NewMapCache[K comparable, V any]() *MapCache[K, V] {
+	return &MapCache[K, V]{items: make(map[K]V)}
+}
+
+// Get returns the cached value and true if the key is present.
+func (c *MapCache[K, V]) Get(k K) (V, bool) {
+	c.lock.RLock()
+	v, ok := c.items[k]
+	c.lock.RUnlock()
+	c.stats.record(ok)
+	return v, ok
+}
+
+// peek returns the cached value without counting the lookup.
+func (c *MapCache[K, V]) peek(k K) (V, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	v, ok := c.items[k]
+	return v, ok
+}
+
+// Set stores the value for the key.
+func (c *MapCache[K, V]) Set(k K, v V) {
+	c.lock.Lock()
+	c.items[k] = v
+	c.lock.Unlock()
+}
+
+// Len returns the number of entries currently held.
+func (c *MapCache[K, V]) Len() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return len(c.items)
+}
+
+// Stats returns a snapshot of the hit, miss and eviction counters.
+func (c *MapCache[K, V]) Stats() CacheStats {
+	return c.stats.snapshot()
+}
+
+// entry is a key-value pair stored in the list based caches.
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+	// freq is only used by LFUCache.
+	freq int
+	// expire is only used by TTLCache.
+	expire time.Time
+}
+
+// peekEntry returns the value of the entry held for k in a list based cache.
+func peekEntry[K comparable, V any](items map[K]*list.Element, k K) (V, bool) {
+	e, ok := items[k]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.Value.(*entry[K, V]).value, true
+}
+
+// LRUCache is a bounded cache that evicts the least recently used entry.
+type LRUCache[K comparable, V any] struct {
+	lock  sync.Mutex
+	cap   int
+	order *list.List // front is the most recently used
+	items map[K]*list.Element
+	stats cacheCounter
+}
+
+var _ Cache[int, int] = (*LRUCache[int, int])(nil)
+
+// NewLRUCache creates a new LRUCache holding at most cap entries.
+// The capacity must be greater than 0, otherwise it will return an error.
+//
+//	c, _ := NewLRUCache[string, int](128)
+func NewLRUCache[K comparable, V any](cap int) (*LRUCache[K, V], error) {
+	if cap <= 0 {
+		return nil, err.NewCacheCapacityError(cap)
+	}
+	return &LRUCache[K, V]{
+		cap:   cap,
+		order: list.New(),
+		items: make(map[K]*list.Element, cap),
+	}, nil
+}
+
+// Get returns the cached value and true if the key is present.
+// A hit marks the entry as the most recently used one.
+func (c *LRUCache[K, V]) Get(k K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	e, ok := c.items[k]
+	c.stats.record(ok)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*entry[K, V]).value, true
+}
+
+// peek returns the cached value without counting the lookup or marking the entry as used.
+func (c *LRUCache[K, V]) peek(k K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return peekEntry[K, V](c.items, k)
+}
+
+// Set stores the value for the key, evicting the least recently used entry when full.
+func (c *LRUCache[K, V]) Set(k K, v V) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if e, ok := c.items[k]; ok {
+		e.Value.(*entry[K, V]).value = v
+		c.order.MoveToFront(e)
+		return
+	}
+	if c.order.Len() >= c.cap {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*entry[K, V]).key)
+		c.stats.evictions.Add(1)
+	}
+	c.items[k] = c.order.PushFront(&entry[K, V]{key: k, value: v})
+}
+
+// Len returns the number of entries currently held.
+func (c *LRUCache[K, V]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.order.Len()
+}
+
+// Cap returns the capacity of the cache.
+func (c *LRUCache[K, V]) Cap() int {
+	return c.cap
+}
+
+// Stats returns a snapshot of the hit, miss and eviction counters.
+func (c *LRUCache[K, V]) Stats() CacheStats {
+	return c.stats.snapshot()
+}
+
+// LFUCache is a bounded cache that evicts the least frequently used entry.
+// Ties are broken by evicting the least recently used entry among them.
+// All operations are O(1).
+type LFUCache[K comparable, V any] struct {
+	lock    sync.Mutex
+	cap     int
+	minFreq int
+	items   map[K]*list.Element
+	// freqs maps a frequency to its entries, front is the most recently used.
+	freqs map[int]*list.List
+	stats cacheCounter
+}
+
+var _ Cache[int, int] = (*LFUCache[int, int])(nil)
+
+// NewLFUCache creates a new LFUCache holding at most cap entries.
+// The capacity must be greater than 0, otherwise it will return an error.
+func NewLFUCache[K comparable, V any](cap int) (*LFUCache[K, V], error) {
+	if cap <= 0 {
+		return nil, err.NewCacheCapacityError(cap)
+	}
+	return &LFUCache[K, V]{
+		cap:   cap,
+		items: make(map[K]*list.Element, cap),
+		freqs: make(map[int]*list.List),
+	}, nil
+}
+
+// touch moves the entry to the bucket of its next frequency.
+func (c *LFUCache[K, V]) touch(e *list.Element) *list.Element {
+	ent := e.Value.(*entry[K, V])
+	bucket := c.freqs[ent.freq]
+	bucket.Remove(e)
+	if bucket.Len() == 0 {
+		delete(c.freqs, ent.freq)
+		if c.minFreq == ent.freq {
+			c.minFreq++
+		}
+	}
+	ent.freq++
+	return c.bucket(ent.freq).PushFront(ent)
+}
+
+// bucket returns the list of entries with the given frequency, creating it if needed.
+func (c *LFUCache[K, V]) bucket(freq int) *list.List {
+	l, ok := c.freqs[freq]
+	if !ok {
+		l = list.New()
+		c.freqs[freq] = l
+	}
+	return l
+}
+
+// Get returns the cached value and true if the key is present.
+// A hit increases the use frequency of the entry.
+func (c *LFUCache[K, V]) Get(k K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	e, ok := c.items[k]
+	c.stats.record(ok)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	e = c.touch(e)
+	c.items[k] = e
+	return e.Value.(*entry[K, V]).value, true
+}
+
+// peek returns the cached value without counting the lookup or increasing its frequency.
+func (c *LFUCache[K, V]) peek(k K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return peekEntry[K, V](c.items, k)
+}
+
+// Set stores the value for the key, evicting the least frequently used entry when full.
+func (c *LFUCache[K, V]) Set(k K, v V) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if e, ok := c.items[k]; ok {
+		e = c.touch(e)
+		e.Value.(*entry[K, V]).value = v
+		c.items[k] = e
+		return
+	}
+	if len(c.items) >= c.cap {
+		bucket := c.freqs[c.minFreq]
+		victim := bucket.Back()
+		bucket.Remove(victim)
+		if bucket.Len() == 0 {
+			delete(c.freqs, c.minFreq)
+		}
+		delete(c.items, victim.Value.(*entry[K, V]).key)
+		c.stats.evictions.Add(1)
+	}
+	c.minFreq = 1
+	c.items[k] = c.bucket(1).PushFront(&entry[K, V]{key: k, value: v, freq: 1})
+}
+
+// Len returns the number of entries currently held.
+func (c *LFUCache[K, V]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.items)
+}
+
+// Cap returns the capacity of the cache.
+func (c *LFUCache[K, V]) Cap() int {
+	return c.cap
+}
+
+// Stats returns a snapshot of the hit, miss and eviction counters.
+func (c *LFUCache[K, V]) Stats() CacheStats {
+	return c.stats.snapshot()
+}
+
+// TTLCache is an unbounded cache whose entries expire after a fixed time-to-live.
+// Expired entries are evicted lazily on Get and Set.
+type TTLCache[K comparable, V any] struct {
+	lock  sync.Mutex
+	ttl   time.Duration
+	order *list.List // front expires first
+	items map[K]*list.Element
+	stats cacheCounter
+}
+
+var _ Cache[int, int] = (*TTLCache[int, int])(nil)
+
+// NewTTLCache creates a new TTLCache whose entries live for ttl.
+// The ttl must be greater than 0, otherwise it will return an error.
+//
+//	c, _ := NewTTLCache[string, int](time.Minute)
+func NewTTLCache[K comparable, V any](ttl time.Duration) (*TTLCache[K, V], error) {
+	if ttl <= 0 {
+		return nil, err.NewInvalidTimeIntervalError(ttl)
+	}
+	return &TTLCache[K, V]{
+		ttl:   ttl,
+		order: list.New(),
+		items: make(map[K]*list.Element),
+	}, nil
+}
+
+// expire evicts every entry whose time-to-live has passed.
+// As all entries share the same ttl, the list is ordered by expiry time.
+func (c *TTLCache[K, V]) expire(now time.Time) {
+	for e := c.order.Front(); e != nil; e = c.order.Front() {
+		ent := e.Value.(*entry[K, V])
+		if now.Before(ent.expire) {
+			return
+		}
+		c.order.Remove(e)
+		delete(c.items, ent.key)
+		c.stats.evictions.Add(1)
+	}
+}
+
+// Get returns the cached value and true if the key is present and not expired.
+func (c *TTLCache[K, V]) Get(k K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.expire(time.Now())
+	e, ok := c.items[k]
+	c.stats.record(ok)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.Value.(*entry[K, V]).value, true
+}
+
+// peek returns the cached value without counting the lookup.
+func (c *TTLCache[K, V]) peek(k K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.expire(time.Now())
+	return peekEntry[K, V](c.items, k)
+}
+
+// Set stores the value for the key and restarts its time-to-live.
+func (c *TTLCache[K, V]) Set(k K, v V) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := time.Now()
+	c.expire(now)
+	if e, ok := c.items[k]; ok {
+		c.order.Remove(e)
+	}
+	c.items[k] = c.order.PushBack(&entry[K, V]{key: k, value: v, expire: now.Add(c.ttl)})
+}
+
+// Len returns the number of entries currently held, including the expired ones not yet evicted.
+func (c *TTLCache[K, V]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.order.Len()
+}
+
+// TTL returns the time-to-live of the entries.
+func (c *TTLCache[K, V]) TTL() time.Duration {
+	return c.ttl
+}
+
+// Stats returns a snapshot of the hit, miss and eviction counters.
+func (c *TTLCache[K, V]) Stats() CacheStats {
+	return c.stats.snapshot()
+}
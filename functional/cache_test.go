@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package functional_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapCache(t *testing.T) {
+	c := functional.NewMapCache[string, int]()
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, functional.CacheStats{Hits: 1, Misses: 1}, c.Stats())
+}
+
+func TestLRUCache(t *testing.T) {
+	c, err := functional.NewLRUCache[int, string](0)
+	assert.Nil(t, c)
+	assert.Error(t, err)
+
+	c, err = functional.NewLRUCache[int, string](2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, c.Cap())
+
+	c.Set(1, "one")
+	c.Set(2, "two")
+	// 1 becomes the most recently used, so 2 is evicted
+	v, ok := c.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "one", v)
+	c.Set(3, "three")
+
+	_, ok = c.Get(2)
+	assert.False(t, ok)
+	_, ok = c.Get(3)
+	assert.True(t, ok)
+	assert.Equal(t, 2, c.Len())
+
+	// updating an existing key does not evict
+	c.Set(3, "drei")
+	v, _ = c.Get(3)
+	assert.Equal(t, "drei", v)
+	assert.Equal(t, functional.CacheStats{Hits: 3, Misses: 1, Evictions: 1}, c.Stats())
+}
+
+func TestLFUCache(t *testing.T) {
+	c, err := functional.NewLFUCache[int, int](-1)
+	assert.Nil(t, c)
+	assert.Error(t, err)
+
+	c, err = functional.NewLFUCache[int, int](2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, c.Cap())
+
+	c.Set(1, 10)
+	c.Set(2, 20)
+	c.Get(1)
+	c.Get(1)
+	c.Get(2)
+	// 2 is used less than 1
+	c.Set(3, 30)
+	_, ok := c.Get(2)
+	assert.False(t, ok)
+	v, ok := c.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, 10, v)
+
+	// 3 is the only entry with the minimal frequency
+	c.Set(4, 40)
+	_, ok = c.Get(3)
+	assert.False(t, ok)
+	_, ok = c.Get(4)
+	assert.True(t, ok)
+
+	// the least frequently used entry goes first, whatever its recency
+	c.Set(4, 41)
+	c.Set(5, 50)
+	_, ok = c.Get(4)
+	assert.False(t, ok)
+	assert.Equal(t, 2, c.Len())
+
+	// ties are broken by evicting the least recently used entry
+	c, err = functional.NewLFUCache[int, int](2)
+	assert.NoError(t, err)
+	c.Set(1, 10)
+	c.Set(2, 20)
+	c.Set(3, 30)
+	_, ok = c.Get(1)
+	assert.False(t, ok)
+	_, ok = c.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), c.Stats().Evictions)
+}
+
+func TestTTLCache(t *testing.T) {
+	c, err := functional.NewTTLCache[string, int](-time.Second)
+	assert.Nil(t, c)
+	assert.Error(t, err)
+
+	c, err = functional.NewTTLCache[string, int](50 * time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, 50*time.Millisecond, c.TTL())
+
+	c.Set("a", 1)
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	time.Sleep(80 * time.Millisecond)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.Zero(t, c.Len())
+	assert.Equal(t, functional.CacheStats{Hits: 1, Misses: 1, Evictions: 1}, c.Stats())
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package functional
+
+import "sync"
+
+// Memoize returns a memoized version of a pure function that takes 1 argument.
+// Results are stored in the given cache, a nil cache means an unbounded MapCache.
+// Concurrent calls with the same argument are coalesced into a single call of fn.
+//
+//	lru, _ := NewLRUCache[int, int](128)
+//	fib := Memoize(slowFib, lru)
+//	fib(40) // computed once
+//	fib(40) // served from the cache
+//	lru.Stats() // {Hits: 1, Misses: 1, Evictions: 0}
+//
+// It also works with the Curry family, caching the partially applied functions:
+//
+//	add := Memoize(Curry2(func(a, b int) int { return a + b }), nil)
+//	add(1)(2) // 3, add(1) is cached
+func Memoize[K comparable, V any](fn func(K) V, cache Cache[K, V]) func(K) V {
+	if cache == nil {
+		cache = NewMapCache[K, V]()
+	}
+	g := &flightGroup[K, V]{}
+	return func(k K) V {
+		if v, ok := cache.Get(k); ok {
+			return v
+		}
+		return g.do(k, func() V {
+			// a flight for k may have finished between the Get above and do
+			if v, ok := recheck(cache, k); ok {
+				return v
+			}
+			v := fn(k)
+			cache.Set(k, v)
+			return v
+		})
+	}
+}
+
+// recheck looks k up again in the cache once a flight for it has started.
+// The caches of this package do not count this lookup, other caches count it as a second Get.
+func recheck[K comparable, V any](cache Cache[K, V], k K) (V, bool) {
+	if p, ok := cache.(peeker[K, V]); ok {
+		return p.peek(k)
+	}
+	return cache.Get(k)
+}
+
+// Args2 is the cache key of a function memoized by Memoize2.
+type Args2[A, B comparable] struct {
+	A A
+	B B
+}
+
+// Args3 is the cache key of a function memoized by Memoize3.
+type Args3[A, B, C comparable] struct {
+	A A
+	B B
+	C C
+}
+
+// Args4 is the cache key of a function memoized by Memoize4.
+type Args4[A, B, C, D comparable] struct {
+	A A
+	B B
+	C C
+	D D
+}
+
+// Memoize2 returns a memoized version of a pure function that takes 2 arguments.
+//
+//	cache, _ := NewLFUCache[Args2[int, int], int](64)
+//	pow := Memoize2(slowPow, cache)
+func Memoize2[A, B comparable, R any](fn func(A, B) R, cache Cache[Args2[A, B], R]) func(A, B) R {
+	m := Memoize(func(k Args2[A, B]) R {
+		return fn(k.A, k.B)
+	}, cache)
+	return func(a A, b B) R {
+		return m(Args2[A, B]{a, b})
+	}
+}
+
+// Memoize3 returns a memoized version of a pure function that takes 3 arguments.
+func Memoize3[A, B, C comparable, R any](fn func(A, B, C) R, cache Cache[Args3[A, B, C], R]) func(A, B, C) R {
+	m := Memoize(func(k Args3[A, B, C]) R {
+		return fn(k.A, k.B, k.C)
+	}, cache)
+	return func(a A, b B, c C) R {
+		return m(Args3[A, B, C]{a, b, c})
+	}
+}
+
+// Memoize4 returns a memoized version of a pure function that takes 4 arguments.
+func Memoize4[A, B, C, D comparable, R any](fn func(A, B, C, D) R, cache Cache[Args4[A, B, C, D], R]) func(A, B, C, D) R {
+	m := Memoize(func(k Args4[A, B, C, D]) R {
+		return fn(k.A, k.B, k.C, k.D)
+	}, cache)
+	return func(a A, b B, c C, d D) R {
+		return m(Args4[A, B, C, D]{a, b, c, d})
+	}
+}
+
+// flightCall is an in-flight or completed call of a flightGroup.
+type flightCall[V any] struct {
+	wg        sync.WaitGroup
+	val       V
+	panicked  bool
+	recovered any
+}
+
+// flightGroup coalesces concurrent calls with the same key, singleflight-style.
+type flightGroup[K comparable, V any] struct {
+	lock  sync.Mutex
+	calls map[K]*flightCall[V]
+}
+
+// do calls fn once for all the concurrent callers with the same key.
+// If fn panics, every caller waiting on it panics with the same value.
+func (g *flightGroup[K, V]) do(k K, fn func() V) V {
+	g.lock.Lock()
+	if g.calls == nil {
+		g.calls = make(map[K]*flightCall[V])
+	}
+	if c, ok := g.calls[k]; ok {
+		g.lock.Unlock()
+		c.wg.Wait()
+		if c.panicked {
+			panic(c.recovered)
+		}
+		return c.val
+	}
+	c := &flightCall[V]{}
+	c.wg.Add(1)
+	g.calls[k] = c
+	g.lock.Unlock()
+
+	returned := false
+	defer func() {
+		if !returned {
+			c.panicked = true
+			c.recovered = recover()
+		}
+		g.lock.Lock()
+		delete(g.calls, k)
+		g.lock.Unlock()
+		c.wg.Done()
+		if c.panicked {
+			panic(c.recovered)
+		}
+	}()
+	c.val = fn()
+	returned = true
+	return c.val
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package functional_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoize(t *testing.T) {
+	calls := 0
+	double := functional.Memoize(func(x int) int {
+		calls++
+		return x * 2
+	}, nil)
+	assert.Equal(t, 4, double(2))
+	assert.Equal(t, 4, double(2))
+	assert.Equal(t, 6, double(3))
+	assert.Equal(t, 2, calls)
+}
+
+func TestMemoizeWithCache(t *testing.T) {
+	lru, err := functional.NewLRUCache[int, int](1)
+	assert.NoError(t, err)
+	calls := 0
+	square := functional.Memoize(func(x int) int {
+		calls++
+		return x * x
+	}, lru)
+	square(2)
+	square(2)
+	square(3) // evicts 2
+	square(2)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, functional.CacheStats{Hits: 1, Misses: 3, Evictions: 2}, lru.Stats())
+}
+
+func TestMemoizeCurried(t *testing.T) {
+	calls := 0
+	add := functional.Memoize(functional.Curry2(func(a, b int) int {
+		return a + b
+	}), nil)
+	addOne := functional.Memoize(func(a int) func(int) int {
+		calls++
+		return add(a)
+	}, nil)
+	assert.Equal(t, 3, addOne(1)(2))
+	assert.Equal(t, 4, addOne(1)(3))
+	assert.Equal(t, 1, calls)
+}
+
+func TestMemoizeN(t *testing.T) {
+	calls := 0
+	add2 := functional.Memoize2(func(a, b int) int {
+		calls++
+		return a + b
+	}, nil)
+	assert.Equal(t, 3, add2(1, 2))
+	assert.Equal(t, 3, add2(1, 2))
+	assert.Equal(t, 4, add2(2, 2))
+	assert.Equal(t, 2, calls)
+
+	cache, err := functional.NewLRUCache[functional.Args3[string, string, string], string](8)
+	assert.NoError(t, err)
+	join3 := functional.Memoize3(func(a, b, c string) string {
+		return a + b + c
+	}, cache)
+	assert.Equal(t, "abc", join3("a", "b", "c"))
+	assert.Equal(t, "abc", join3("a", "b", "c"))
+	assert.Equal(t, uint64(1), cache.Stats().Hits)
+
+	add4 := functional.Memoize4(func(a, b, c, d int) int {
+		return a + b + c + d
+	}, nil)
+	assert.Equal(t, 10, add4(1, 2, 3, 4))
+}
+
+func TestMemoizeDeduplicate(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	slow := functional.Memoize(func(x int) int {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return x + 1
+	}, nil)
+
+	const n = 32
+	var wg sync.WaitGroup
+	results := make([]int, n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = slow(41)
+		}()
+	}
+	// give every goroutine the chance to join the in-flight call
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, r := range results {
+		assert.Equal(t, 42, r)
+	}
+}
+
+func TestMemoizePanic(t *testing.T) {
+	calls := 0
+	fn := functional.Memoize(func(x int) int {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+		return x
+	}, nil)
+	assert.PanicsWithValue(t, "boom", func() { fn(1) })
+	// a panicking call is not cached
+	assert.Equal(t, 1, fn(1))
+	assert.Equal(t, 2, calls)
+}
+
+// lateCache finishes a whole memoized call right after the first miss it reports,
+// as if another goroutine's flight completed between the miss and the caller's flight.
+type lateCache struct {
+	*functional.MapCache[int, int]
+	during func()
+}
+
+func (c *lateCache) Get(k int) (int, bool) {
+	v, ok := c.MapCache.Get(k)
+	if during := c.during; !ok && during != nil {
+		c.during = nil
+		during()
+	}
+	return v, ok
+}
+
+func TestMemoizeRecheck(t *testing.T) {
+	calls := 0
+	cache := &lateCache{MapCache: functional.NewMapCache[int, int]()}
+	fn := functional.Memoize(func(x int) int {
+		calls++
+		return x * 2
+	}, cache)
+	cache.during = func() { fn(1) }
+
+	assert.Equal(t, 2, fn(1))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, functional.CacheStats{Hits: 0, Misses: 2}, cache.Stats())
+}
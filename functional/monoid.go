@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package functional
+
+// Monoid is an associative binary operation with an identity element:
+// Combine(Combine(a, b), c) == Combine(a, Combine(b, c)) and Combine(Empty(), a) == Combine(a, Empty()) == a.
+// The laws package checks these laws for custom instances.
+type Monoid[T any] interface {
+	Empty() T
+	Combine(T, T) T
+}
+
+// monoid is a Monoid made of an identity element and a function.
+type monoid[T any] struct {
+	empty   T
+	combine func(T, T) T
+}
+
+func (m monoid[T]) Empty() T {
+	return m.empty
+}
+
+func (m monoid[T]) Combine(a, b T) T {
+	return m.combine(a, b)
+}
+
+// NewMonoid returns the Monoid of the identity element and the associative operation.
+//	maxMonoid := NewMonoid(math.MinInt, func(a, b int) int { return max(a, b) })
+func NewMonoid[T any](empty T, combine func(T, T) T) Monoid[T] {
+	return monoid[T]{empty: empty, combine: combine}
+}
+
+// SumMonoid returns the Monoid of the addition, with identity 0.
+// Floating-point addition is only approximately associative.
+func SumMonoid[T Numeric]() Monoid[T] {
+	return NewMonoid(T(0), func(a, b T) T { return a + b })
+}
+
+// ProductMonoid returns the Monoid of the multiplication, with identity 1.
+func ProductMonoid[T Numeric]() Monoid[T] {
+	return NewMonoid(T(1), func(a, b T) T { return a * b })
+}
+
+// StringMonoid returns the Monoid of the string concatenation, with identity "".
+func StringMonoid() Monoid[string] {
+	return NewMonoid("", func(a, b string) string { return a + b })
+}
+
+// SliceMonoid returns the Monoid of the slice concatenation, with identity nil.
+// Combine returns a new slice, or the other slice if one of them is nil.
+func SliceMonoid[T any]() Monoid[[]T] {
+	return NewMonoid[[]T](nil, func(a, b []T) []T {
+		switch {
+		case a == nil:
+			return b
+		case b == nil:
+			return a
+		}
+		return append(append(make([]T, 0, len(a)+len(b)), a...), b...)
+	})
+}
+
+// FoldMonoid combines all the elements with the Monoid, Empty if there is none.
+//	FoldMonoid(SumMonoid[int](), 1, 2, 3) // 6
+func FoldMonoid[T any](m Monoid[T], s ...T) T {
+	return Foldl(s, m.Combine, m.Empty())
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package functional_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonoids(t *testing.T) {
+	assert.Equal(t, 6, functional.FoldMonoid(functional.SumMonoid[int](), 1, 2, 3))
+	assert.Equal(t, 0, functional.FoldMonoid(functional.SumMonoid[int]()))
+	assert.Equal(t, 24.0, functional.FoldMonoid(functional.ProductMonoid[float64](), 1, 2, 3, 4))
+	assert.Equal(t, "abc", functional.FoldMonoid(functional.StringMonoid(), "a", "b", "c"))
+	assert.Equal(t, []int{1, 2, 3}, functional.FoldMonoid(functional.SliceMonoid[int](), []int{1}, nil, []int{2, 3}))
+	assert.Nil(t, functional.FoldMonoid(functional.SliceMonoid[int](), nil, nil))
+	assert.Equal(t, []int{}, functional.FoldMonoid(functional.SliceMonoid[int](), nil, []int{}))
+
+	maxMonoid := functional.NewMonoid(math.MinInt, func(a, b int) int { return max(a, b) })
+	assert.Equal(t, 5, functional.FoldMonoid(maxMonoid, 3, 5, 1))
+	assert.Equal(t, math.MinInt, functional.FoldMonoid(maxMonoid))
+
+	// slices are not shared
+	a := make([]int, 1, 10)
+	b := functional.SliceMonoid[int]().Combine(a, []int{1})
+	b[0] = 42
+	assert.Equal(t, 0, a[0])
+}
@@ -41,6 +41,18 @@ func CompareTo[T Orderable](a, b T) int {
 	return 0
 }
 
+// Less is the Comparator of the ascending order.
+//	Less(1, 2) // => true
+func Less[T Orderable](a, b T) bool {
+	return a < b
+}
+
+// Greater is the Comparator of the descending order.
+//	Greater(1, 2) // => false
+func Greater[T Orderable](a, b T) bool {
+	return a > b
+}
+
 // Sort sorts the given slice in place using the given comparator.
 //	Sort([]int{3, 1, 2}, func(a, b int) bool { return a < b }) // => []int{1, 2, 3}
 func Sort[T Orderable](a []T, cmp Comparator[T]) {
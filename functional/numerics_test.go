@@ -201,3 +201,15 @@ func TestSortDesc(t *testing.T) {
 		assert.Equal(t, c.want, functional.SortDesc(c.in...))
 	}
 }
+
+func TestLessGreater(t *testing.T) {
+	assert.True(t, functional.Less(1, 2))
+	assert.False(t, functional.Less(2, 2))
+	assert.True(t, functional.Greater("b", "a"))
+	assert.False(t, functional.Greater(1.0, 1.0))
+	assert.Equal(t, []int{1, 2, 3}, functional.SortAsc(3, 1, 2))
+
+	s := []int{3, 1, 2}
+	functional.Sort(s, functional.Greater[int])
+	assert.Equal(t, []int{3, 2, 1}, s)
+}
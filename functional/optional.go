@@ -100,6 +100,14 @@ func Just[T any](value T) Optional[T] {
 	return maybe[T]{value: value, isNil: isNil}
 }
 
+// Nothing: Nothing[T]() Optional[T]
+//	n := Nothing[int]() // n is a Optional[int] object without value
+//	k := n.OrElse(0)    // k is 0
+//	n.IsPresent()       // false
+func Nothing[T any]() Optional[T] {
+	return maybe[T]{isNil: true}
+}
+
 // MakeClone: make a clone of the Optional object
 //	j := Just(42) // j is a Optional[int] object with value 42
 //	ptr := new(int)
@@ -185,10 +193,38 @@ func (m maybe[T]) Clone() Optional[T] {
 //	k := None.FlatMap(func(v any) functional.Optional[any] { return Just(42) })
 //	// k is a Optional[any] object with value 42
 //	l := k.Unwrap() // l is 42
+//
+// Note that the function is called on missing values too, with the zero value,
+// so use OptionalFlatMap for the lawful Haskell >>=.
 func (m maybe[T]) FlatMap(fn func(T) Optional[T]) Optional[T] {
 	return fn(m.value)
 }
 
+// OptionalMap: applies the function to the value if present, otherwise returns Nothing.
+// Unlike the FlatMap method, it never calls the function on a missing value,
+// and it may change the type of the value.
+//	OptionalMap(Just(21), func(x int) string { return strconv.Itoa(x * 2) }) // Just("42")
+//	OptionalMap(Nothing[int](), strconv.Itoa)                             // Nothing[string]()
+func OptionalMap[T, U any](m Optional[T], fn func(T) U) Optional[U] {
+	if !m.IsPresent() {
+		return Nothing[U]()
+	}
+	return Just(fn(m.Unwrap()))
+}
+
+// OptionalFlatMap: chains a computation that may return nothing after the Optional,
+// the Haskell >>= of Maybe: Nothing >>= f is Nothing.
+// Unlike the FlatMap method, it never calls the function on a missing value,
+// and it may change the type of the value.
+//	OptionalFlatMap(Just("42"), parse) // Just(42)
+//	OptionalFlatMap(Nothing[string](), parse) // Nothing[int]()
+func OptionalFlatMap[T, U any](m Optional[T], fn func(T) Optional[U]) Optional[U] {
+	if !m.IsPresent() {
+		return Nothing[U]()
+	}
+	return fn(m.Unwrap())
+}
+
 // IfPresent: if the value is present, then apply the function, otherwise do nothing
 func (m maybe[T]) IfPresent(fn func()) {
 	if m.IsPresent() {
@@ -62,6 +62,19 @@ func TestNone(t *testing.T) {
 	}
 }
 
+func TestNothing(t *testing.T) {
+	opt := functional.Nothing[int]()
+	if opt.IsPresent() {
+		t.Error("Expected IsPresent to be false")
+	}
+	if !opt.IsNil() {
+		t.Error("Expected IsNil to be true")
+	}
+	if opt.OrElse(7) != 7 {
+		t.Errorf("Expected OrElse to return 7, got %v", opt.OrElse(7))
+	}
+}
+
 func TestOrElse(t *testing.T) {
 	opt := functional.Just(42)
 	if opt.OrElse(0) != 42 {
@@ -245,3 +258,44 @@ func TestUnWrapNone(t *testing.T) {
 		t.Error("Expected UnwrapAny to return nil")
 	}
 }
+
+func TestOptionalMap(t *testing.T) {
+	half := functional.OptionalMap(functional.Just(10), func(v int) float64 { return float64(v) / 4 })
+	if !half.IsPresent() || half.Unwrap() != 2.5 {
+		t.Errorf("Expected OptionalMap result to be present and equal to 2.5, got %v", half.Unwrap())
+	}
+
+	called := false
+	none := functional.OptionalMap(functional.Nothing[int](), func(v int) string {
+		called = true
+		return "x"
+	})
+	if none.IsPresent() {
+		t.Error("Expected OptionalMap of Nothing to be Nothing")
+	}
+	if called {
+		t.Error("Expected OptionalMap not to call the function on Nothing")
+	}
+}
+
+func TestOptionalFlatMap(t *testing.T) {
+	safeDivide := func(x int) functional.Optional[float64] {
+		if x == 0 {
+			return functional.Nothing[float64]()
+		}
+		return functional.Just(1 / float64(x))
+	}
+	if result := functional.OptionalFlatMap(functional.Just(2), safeDivide); !result.IsPresent() || result.Unwrap() != 0.5 {
+		t.Errorf("Expected OptionalFlatMap result to be present and equal to 0.5, got %v", result.Unwrap())
+	}
+	if functional.OptionalFlatMap(functional.Just(0), safeDivide).IsPresent() {
+		t.Error("Expected OptionalFlatMap result to be Nothing")
+	}
+	// Nothing >>= f is Nothing, unlike with the FlatMap method
+	if functional.OptionalFlatMap(functional.Nothing[int](), safeDivide).IsPresent() {
+		t.Error("Expected OptionalFlatMap of Nothing to be Nothing")
+	}
+	if !functional.Nothing[int]().FlatMap(functional.Just[int]).IsPresent() {
+		t.Error("Expected the FlatMap method to call the function on Nothing")
+	}
+}
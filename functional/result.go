@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package functional
+
+// Result holds either a value or an error, it is the outcome of a computation that may fail.
+//
+//	r := Ok(42)
+//	r.IsOk()        // true
+//	v, err := r.Get() // 42, nil
+//	Err[int](io.EOF).OrElse(0) // 0
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok returns a successful Result holding the value.
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+// Err returns a failed Result holding the error.
+// A nil error gives a successful Result holding the zero value.
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// ResultOf returns a Result from the usual value and error pair.
+//
+//	ResultOf(strconv.Atoi("42")) // Ok(42)
+func ResultOf[T any](value T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(value)
+}
+
+// IsOk returns true if the Result holds a value.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// IsErr returns true if the Result holds an error.
+func (r Result[T]) IsErr() bool {
+	return r.err != nil
+}
+
+// Get returns the value and the error of the Result.
+func (r Result[T]) Get() (T, error) {
+	return r.value, r.err
+}
+
+// Value returns the value of the Result, the zero value of T if it failed.
+func (r Result[T]) Value() T {
+	return r.value
+}
+
+// Err returns the error of the Result, nil if it succeeded.
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// OrElse returns the value if the Result succeeded, otherwise the default value.
+func (r Result[T]) OrElse(defaultValue T) T {
+	if r.IsErr() {
+		return defaultValue
+	}
+	return r.value
+}
+
+// FlatMap applies the function to the value if the Result succeeded,
+// otherwise it passes the error on.
+func (r Result[T]) FlatMap(fn func(T) Result[T]) Result[T] {
+	return ResultFlatMap(r, fn)
+}
+
+// ToOptional returns the value as an Optional, Nothing if the Result failed.
+func (r Result[T]) ToOptional() Optional[T] {
+	if r.IsErr() {
+		return Nothing[T]()
+	}
+	return Just(r.value)
+}
+
+// ResultMap applies the function to the value if the Result succeeded,
+// otherwise it passes the error on.
+//
+//	ResultMap(Ok(21), func(x int) string { return strconv.Itoa(x * 2) }) // Ok("42")
+func ResultMap[T, U any](r Result[T], fn func(T) U) Result[U] {
+	if r.IsErr() {
+		return Err[U](r.err)
+	}
+	return Ok(fn(r.value))
+}
+
+// ResultFlatMap chains a computation that may fail after the Result.
+//
+//	ResultFlatMap(Ok("42"), func(s string) Result[int] { return ResultOf(strconv.Atoi(s)) }) // Ok(42)
+func ResultFlatMap[T, U any](r Result[T], fn func(T) Result[U]) Result[U] {
+	if r.IsErr() {
+		return Err[U](r.err)
+	}
+	return fn(r.value)
+}
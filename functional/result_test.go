@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package functional_test
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResult(t *testing.T) {
+	ok := functional.Ok(42)
+	assert.True(t, ok.IsOk())
+	assert.False(t, ok.IsErr())
+	v, err := ok.Get()
+	assert.Equal(t, 42, v)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, ok.OrElse(0))
+	assert.True(t, ok.ToOptional().IsPresent())
+
+	bad := functional.Err[int](io.EOF)
+	assert.False(t, bad.IsOk())
+	assert.True(t, bad.IsErr())
+	assert.ErrorIs(t, bad.Err(), io.EOF)
+	assert.Zero(t, bad.Value())
+	assert.Equal(t, 7, bad.OrElse(7))
+	assert.False(t, bad.ToOptional().IsPresent())
+}
+
+func TestResultOf(t *testing.T) {
+	assert.Equal(t, functional.Ok(42), functional.ResultOf(strconv.Atoi("42")))
+	assert.True(t, functional.ResultOf(strconv.Atoi("forty-two")).IsErr())
+}
+
+func TestResultMap(t *testing.T) {
+	toString := func(x int) string { return strconv.Itoa(x * 2) }
+	assert.Equal(t, functional.Ok("42"), functional.ResultMap(functional.Ok(21), toString))
+
+	failed := functional.ResultMap(functional.Err[int](io.EOF), toString)
+	assert.ErrorIs(t, failed.Err(), io.EOF)
+
+	parse := func(s string) functional.Result[int] { return functional.ResultOf(strconv.Atoi(s)) }
+	assert.Equal(t, functional.Ok(42), functional.ResultFlatMap(functional.Ok("42"), parse))
+	assert.True(t, functional.ResultFlatMap(functional.Ok("x"), parse).IsErr())
+	assert.ErrorIs(t, functional.ResultFlatMap(functional.Err[string](io.EOF), parse).Err(), io.EOF)
+
+	errOdd := errors.New("odd")
+	half := func(x int) functional.Result[int] {
+		if x%2 != 0 {
+			return functional.Err[int](errOdd)
+		}
+		return functional.Ok(x / 2)
+	}
+	assert.Equal(t, functional.Ok(21), functional.Ok(84).FlatMap(half).FlatMap(half))
+	assert.ErrorIs(t, functional.Ok(42).FlatMap(half).FlatMap(half).Err(), errOdd)
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package functional
+
+// Trampoline is a step of a recursive computation that can be run in constant stack space.
+// Instead of calling itself, a recursive function returns either Done with the result,
+// or More with a thunk computing the next step; Run then loops over the steps.
+//
+//	var even, odd func(n int) Trampoline[bool]
+//	even = func(n int) Trampoline[bool] {
+//		if n == 0 {
+//			return Done(true)
+//		}
+//		return More(func() Trampoline[bool] { return odd(n - 1) })
+//	}
+//	odd = func(n int) Trampoline[bool] {
+//		if n == 0 {
+//			return Done(false)
+//		}
+//		return More(func() Trampoline[bool] { return even(n - 1) })
+//	}
+//	even(100_000_000).Run() // true, without growing the stack
+type Trampoline[T any] struct {
+	value T
+	thunk func() Trampoline[T]
+	// bind is set for the steps built by TrampolineBind.
+	bind *step
+}
+
+// step is the type-erased representation of a Trampoline,
+// so that steps of different types can be chained by TrampolineBind.
+// Exactly one of thunk and sub is set, or none for a finished step.
+type step struct {
+	value any
+	thunk func() *step
+	sub   *step
+	cont  func(any) *step
+}
+
+// erase converts the Trampoline into a step.
+func (t Trampoline[T]) erase() *step {
+	switch {
+	case t.bind != nil:
+		return t.bind
+	case t.thunk != nil:
+		return &step{thunk: func() *step { return t.thunk().erase() }}
+	default:
+		return &step{value: t.value}
+	}
+}
+
+// Done returns a finished Trampoline holding the result.
+func Done[T any](value T) Trampoline[T] {
+	return Trampoline[T]{value: value}
+}
+
+// More returns a Trampoline whose next step is computed by the thunk.
+func More[T any](thunk func() Trampoline[T]) Trampoline[T] {
+	return Trampoline[T]{thunk: thunk}
+}
+
+// TrampolineBind chains a computation after the Trampoline, feeding it the result.
+// It is what makes non-tail recursion, like Foldr or a tree walk, stack-safe:
+// the continuations are kept on a heap-allocated stack by Run.
+//
+//	var sum func(n int) Trampoline[int]
+//	sum = func(n int) Trampoline[int] {
+//		if n == 0 {
+//			return Done(0)
+//		}
+//		return More(func() Trampoline[int] {
+//			return TrampolineBind(sum(n-1), func(acc int) Trampoline[int] { return Done(acc + n) })
+//		})
+//	}
+func TrampolineBind[A, B any](t Trampoline[A], fn func(A) Trampoline[B]) Trampoline[B] {
+	return Trampoline[B]{bind: &step{sub: t.erase(), cont: func(v any) *step {
+		// a nil interface A is stored as a nil any, which does not assert to A
+		a, _ := v.(A)
+		return fn(a).erase()
+	}}}
+}
+
+// TrampolineMap applies the function to the result of the Trampoline.
+func TrampolineMap[A, B any](t Trampoline[A], fn func(A) B) Trampoline[B] {
+	return TrampolineBind(t, func(a A) Trampoline[B] {
+		return Done(fn(a))
+	})
+}
+
+// FlatMap chains a computation of the same type after the Trampoline.
+func (t Trampoline[T]) FlatMap(fn func(T) Trampoline[T]) Trampoline[T] {
+	return TrampolineBind(t, fn)
+}
+
+// Run evaluates the Trampoline step by step in a loop and returns the result.
+func (t Trampoline[T]) Run() T {
+	// Plain tail calls need no type erasure.
+	for t.bind == nil {
+		if t.thunk == nil {
+			return t.value
+		}
+		t = t.thunk()
+	}
+
+	cur := t.bind
+	var conts []func(any) *step
+	for {
+		switch {
+		case cur.thunk != nil:
+			cur = cur.thunk()
+		case cur.sub != nil:
+			conts = append(conts, cur.cont)
+			cur = cur.sub
+		case len(conts) == 0:
+			v, _ := cur.value.(T)
+			return v
+		default:
+			k := conts[len(conts)-1]
+			conts[len(conts)-1] = nil
+			conts = conts[:len(conts)-1]
+			cur = k(cur.value)
+		}
+	}
+}
+
+// FoldrTrampoline is the right fold written as a genuine recursion,
+// fn(fn(fn(init, s[n-1]), ...), s[0]), evaluated in constant stack space.
+// It returns the same result as Foldr, and is meant as a template
+// for recursive folds over structures that cannot be indexed backwards.
+//
+//	FoldrTrampoline([]int{1, 2, 3}, func(acc, x int) int { return acc*10 + x }, 0) // 321
+func FoldrTrampoline[T any, U any](s []T, fn func(U, T) U, init U) U {
+	var fold func(i int) Trampoline[U]
+	fold = func(i int) Trampoline[U] {
+		if i == len(s) {
+			return Done(init)
+		}
+		return More(func() Trampoline[U] {
+			return TrampolineBind(fold(i+1), func(acc U) Trampoline[U] {
+				return Done(fn(acc, s[i]))
+			})
+		})
+	}
+	return fold(0).Run()
+}
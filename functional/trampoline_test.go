@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package functional_test
+
+import (
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// deep is the depth of the tail calls, which run in constant memory.
+const deep = 10_000_001
+
+// deepBind is the depth of the non-tail recursions,
+// whose pending continuations are kept on the heap.
+// They take seconds each, so -short runs shortBind instead.
+const (
+	deepBind  = 10_000_001
+	shortBind = 100_001
+)
+
+// bindDepth returns the depth of the non-tail recursions for this run.
+func bindDepth() int {
+	if testing.Short() {
+		return shortBind
+	}
+	return deepBind
+}
+
+func TestTrampolineTailCall(t *testing.T) {
+	var countdown func(n int) functional.Trampoline[int]
+	countdown = func(n int) functional.Trampoline[int] {
+		if n == 0 {
+			return functional.Done(0)
+		}
+		return functional.More(func() functional.Trampoline[int] {
+			return countdown(n - 1)
+		})
+	}
+	assert.Equal(t, 0, countdown(deep).Run())
+	assert.Equal(t, 42, functional.Done(42).Run())
+}
+
+func TestTrampolineMutualRecursion(t *testing.T) {
+	var even, odd func(n int) functional.Trampoline[bool]
+	even = func(n int) functional.Trampoline[bool] {
+		if n == 0 {
+			return functional.Done(true)
+		}
+		return functional.More(func() functional.Trampoline[bool] { return odd(n - 1) })
+	}
+	odd = func(n int) functional.Trampoline[bool] {
+		if n == 0 {
+			return functional.Done(false)
+		}
+		return functional.More(func() functional.Trampoline[bool] { return even(n - 1) })
+	}
+	assert.False(t, even(deep).Run())
+	assert.True(t, odd(deep).Run())
+}
+
+func TestTrampolineBind(t *testing.T) {
+	// sum(n) = n + sum(n-1) is not a tail call
+	var sum func(n int) functional.Trampoline[int]
+	sum = func(n int) functional.Trampoline[int] {
+		if n == 0 {
+			return functional.Done(0)
+		}
+		return functional.More(func() functional.Trampoline[int] {
+			return functional.TrampolineBind(sum(n-1), func(acc int) functional.Trampoline[int] {
+				return functional.Done(acc + n)
+			})
+		})
+	}
+	n := bindDepth()
+	assert.Equal(t, n*(n+1)/2, sum(n).Run())
+
+	str := functional.TrampolineMap(functional.Done(21), func(x int) string {
+		return string(rune('A' + x))
+	})
+	assert.Equal(t, "V", str.Run())
+
+	doubled := functional.Done(21).FlatMap(func(x int) functional.Trampoline[int] {
+		return functional.Done(x * 2)
+	})
+	assert.Equal(t, 42, doubled.Run())
+}
+
+func TestTrampolineNilInterface(t *testing.T) {
+	// a nil interface result goes through the type-erased steps
+	isNil := functional.TrampolineMap(functional.Done[error](nil), func(e error) bool { return e == nil })
+	assert.True(t, isNil.Run())
+
+	var noErr error
+	assert.NotPanics(t, func() {
+		noErr = functional.TrampolineMap(functional.Done(1), func(int) error { return nil }).Run()
+	})
+	assert.Nil(t, noErr)
+
+	passed := functional.TrampolineBind(functional.Done[any](nil), func(v any) functional.Trampoline[any] {
+		return functional.Done(v)
+	})
+	assert.Nil(t, passed.Run())
+}
+
+func TestFoldrTrampoline(t *testing.T) {
+	digits := func(acc, x int) int { return acc*10 + x }
+	assert.Equal(t, 321, functional.FoldrTrampoline([]int{1, 2, 3}, digits, 0))
+	assert.Equal(t, 7, functional.FoldrTrampoline([]int{}, digits, 7))
+
+	s := make([]int, bindDepth())
+	for i := range s {
+		s[i] = i % 7
+	}
+	alternate := func(acc, x int) int { return x - acc }
+	assert.Equal(t, functional.Foldr(s, alternate, 0), functional.FoldrTrampoline(s, alternate, 0))
+}
+
+type tree struct {
+	value       int
+	left, right *tree
+}
+
+func TestTrampolineTreeWalk(t *testing.T) {
+	// a degenerate tree, as deep as it has nodes
+	n := bindDepth()
+	var root *tree
+	for i := 1; i <= n; i++ {
+		if i%2 == 0 {
+			root = &tree{value: i, left: root}
+		} else {
+			root = &tree{value: i, right: root}
+		}
+	}
+
+	var walk func(n *tree) functional.Trampoline[int]
+	walk = func(n *tree) functional.Trampoline[int] {
+		if n == nil {
+			return functional.Done(0)
+		}
+		return functional.More(func() functional.Trampoline[int] {
+			return functional.TrampolineBind(walk(n.left), func(l int) functional.Trampoline[int] {
+				return functional.TrampolineMap(walk(n.right), func(r int) int {
+					return l + r + n.value
+				})
+			})
+		})
+	}
+	assert.Equal(t, n*(n+1)/2, walk(root).Run())
+}
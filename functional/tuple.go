@@ -0,0 +1,218 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package functional
+
+// Pair is a generic 2-tuple.
+//
+//	p := PairOf("answer", 42)
+//	k, v := p.Unpack() // "answer", 42
+//	p.Second()         // 42
+type Pair[A, B any] struct {
+	a A
+	b B
+}
+
+// PairOf returns a new Pair holding the given values.
+func PairOf[A, B any](a A, b B) Pair[A, B] {
+	return Pair[A, B]{a, b}
+}
+
+// First returns the first element of the Pair.
+func (p Pair[A, B]) First() A {
+	return p.a
+}
+
+// Second returns the second element of the Pair.
+func (p Pair[A, B]) Second() B {
+	return p.b
+}
+
+// Unpack returns the elements of the Pair.
+func (p Pair[A, B]) Unpack() (A, B) {
+	return p.a, p.b
+}
+
+// Swap returns a new Pair with the elements in reverse order.
+func (p Pair[A, B]) Swap() Pair[B, A] {
+	return Pair[B, A]{p.b, p.a}
+}
+
+// Triple is a generic 3-tuple.
+type Triple[A, B, C any] struct {
+	a A
+	b B
+	c C
+}
+
+// TripleOf returns a new Triple holding the given values.
+func TripleOf[A, B, C any](a A, b B, c C) Triple[A, B, C] {
+	return Triple[A, B, C]{a, b, c}
+}
+
+// First returns the first element of the Triple.
+func (t Triple[A, B, C]) First() A {
+	return t.a
+}
+
+// Second returns the second element of the Triple.
+func (t Triple[A, B, C]) Second() B {
+	return t.b
+}
+
+// Third returns the third element of the Triple.
+func (t Triple[A, B, C]) Third() C {
+	return t.c
+}
+
+// Unpack returns the elements of the Triple.
+func (t Triple[A, B, C]) Unpack() (A, B, C) {
+	return t.a, t.b, t.c
+}
+
+// Tuple4 is a generic 4-tuple.
+type Tuple4[A, B, C, D any] struct {
+	a A
+	b B
+	c C
+	d D
+}
+
+// Tuple4Of returns a new Tuple4 holding the given values.
+func Tuple4Of[A, B, C, D any](a A, b B, c C, d D) Tuple4[A, B, C, D] {
+	return Tuple4[A, B, C, D]{a, b, c, d}
+}
+
+// First returns the first element of the Tuple4.
+func (t Tuple4[A, B, C, D]) First() A {
+	return t.a
+}
+
+// Second returns the second element of the Tuple4.
+func (t Tuple4[A, B, C, D]) Second() B {
+	return t.b
+}
+
+// Third returns the third element of the Tuple4.
+func (t Tuple4[A, B, C, D]) Third() C {
+	return t.c
+}
+
+// Fourth returns the fourth element of the Tuple4.
+func (t Tuple4[A, B, C, D]) Fourth() D {
+	return t.d
+}
+
+// Unpack returns the elements of the Tuple4.
+func (t Tuple4[A, B, C, D]) Unpack() (A, B, C, D) {
+	return t.a, t.b, t.c, t.d
+}
+
+// Tuple5 is a generic 5-tuple.
+type Tuple5[A, B, C, D, E any] struct {
+	a A
+	b B
+	c C
+	d D
+	e E
+}
+
+// Tuple5Of returns a new Tuple5 holding the given values.
+func Tuple5Of[A, B, C, D, E any](a A, b B, c C, d D, e E) Tuple5[A, B, C, D, E] {
+	return Tuple5[A, B, C, D, E]{a, b, c, d, e}
+}
+
+// First returns the first element of the Tuple5.
+func (t Tuple5[A, B, C, D, E]) First() A {
+	return t.a
+}
+
+// Second returns the second element of the Tuple5.
+func (t Tuple5[A, B, C, D, E]) Second() B {
+	return t.b
+}
+
+// Third returns the third element of the Tuple5.
+func (t Tuple5[A, B, C, D, E]) Third() C {
+	return t.c
+}
+
+// Fourth returns the fourth element of the Tuple5.
+func (t Tuple5[A, B, C, D, E]) Fourth() D {
+	return t.d
+}
+
+// Fifth returns the fifth element of the Tuple5.
+func (t Tuple5[A, B, C, D, E]) Fifth() E {
+	return t.e
+}
+
+// Unpack returns the elements of the Tuple5.
+func (t Tuple5[A, B, C, D, E]) Unpack() (A, B, C, D, E) {
+	return t.a, t.b, t.c, t.d, t.e
+}
+
+// Tuple6 is a generic 6-tuple.
+type Tuple6[A, B, C, D, E, F any] struct {
+	a A
+	b B
+	c C
+	d D
+	e E
+	f F
+}
+
+// Tuple6Of returns a new Tuple6 holding the given values.
+func Tuple6Of[A, B, C, D, E, F any](a A, b B, c C, d D, e E, f F) Tuple6[A, B, C, D, E, F] {
+	return Tuple6[A, B, C, D, E, F]{a, b, c, d, e, f}
+}
+
+// First returns the first element of the Tuple6.
+func (t Tuple6[A, B, C, D, E, F]) First() A {
+	return t.a
+}
+
+// Second returns the second element of the Tuple6.
+func (t Tuple6[A, B, C, D, E, F]) Second() B {
+	return t.b
+}
+
+// Third returns the third element of the Tuple6.
+func (t Tuple6[A, B, C, D, E, F]) Third() C {
+	return t.c
+}
+
+// Fourth returns the fourth element of the Tuple6.
+func (t Tuple6[A, B, C, D, E, F]) Fourth() D {
+	return t.d
+}
+
+// Fifth returns the fifth element of the Tuple6.
+func (t Tuple6[A, B, C, D, E, F]) Fifth() E {
+	return t.e
+}
+
+// Sixth returns the sixth element of the Tuple6.
+func (t Tuple6[A, B, C, D, E, F]) Sixth() F {
+	return t.f
+}
+
+// Unpack returns the elements of the Tuple6.
+func (t Tuple6[A, B, C, D, E, F]) Unpack() (A, B, C, D, E, F) {
+	return t.a, t.b, t.c, t.d, t.e, t.f
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package functional_test
+
+import (
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPair(t *testing.T) {
+	p := functional.PairOf("answer", 42)
+	assert.Equal(t, "answer", p.First())
+	assert.Equal(t, 42, p.Second())
+	k, v := p.Unpack()
+	assert.Equal(t, "answer", k)
+	assert.Equal(t, 42, v)
+	assert.Equal(t, functional.PairOf(42, "answer"), p.Swap())
+
+	// pairs of comparable types are comparable
+	assert.True(t, p == functional.PairOf("answer", 42))
+	set := map[functional.Pair[int, int]]bool{functional.PairOf(1, 2): true}
+	assert.True(t, set[functional.PairOf(1, 2)])
+	assert.False(t, set[functional.PairOf(2, 1)])
+}
+
+func TestTriple(t *testing.T) {
+	tr := functional.TripleOf(1, "two", 3.0)
+	assert.Equal(t, 1, tr.First())
+	assert.Equal(t, "two", tr.Second())
+	assert.Equal(t, 3.0, tr.Third())
+	a, b, c := tr.Unpack()
+	assert.Equal(t, 1, a)
+	assert.Equal(t, "two", b)
+	assert.Equal(t, 3.0, c)
+}
+
+func TestTupleN(t *testing.T) {
+	t4 := functional.Tuple4Of(1, 2, 3, 4)
+	assert.Equal(t, 4, t4.Fourth())
+	a, b, c, d := t4.Unpack()
+	assert.Equal(t, []int{1, 2, 3, 4}, []int{a, b, c, d})
+
+	t5 := functional.Tuple5Of(1, 2, 3, 4, "five")
+	assert.Equal(t, "five", t5.Fifth())
+	_, _, _, _, e := t5.Unpack()
+	assert.Equal(t, "five", e)
+
+	t6 := functional.Tuple6Of(1, 2, 3, 4, 5, true)
+	assert.Equal(t, 1, t6.First())
+	assert.True(t, t6.Sixth())
+	a, b, c, d, e6, f := t6.Unpack()
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, []int{a, b, c, d, e6})
+	assert.True(t, f)
+}
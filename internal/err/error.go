@@ -24,7 +24,8 @@ import (
 )
 
 var (
-	ErrEmptyQueue = fmt.Errorf("fpkit: empty queue")
+	ErrEmptyQueue  = fmt.Errorf("fpkit: empty queue")
+	ErrQueueClosed = fmt.Errorf("fpkit: queue closed")
 )
 
 func NewIndexOutOfRangeError(index, length int) error {
@@ -50,3 +51,11 @@ func NewTypeCastError(from any, to string) error {
 func NewInvalidTimeIntervalError(interval time.Duration) error {
 	return fmt.Errorf("fpkit: invalid time interval: [%v]", interval)
 }
+
+func NewCacheCapacityError(cap int) error {
+	return fmt.Errorf("fpkit: invalid cache capacity: %d", cap)
+}
+
+func NewInvalidFieldError(typ any, path, reason string) error {
+	return fmt.Errorf("fpkit: invalid field %s of type %v: %s", path, typ, reason)
+}
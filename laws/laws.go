@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package laws checks that instances satisfy the algebraic laws of their abstractions,
+// against random inputs drawn from proptest generators.
+//
+// Each Check function reports every law which does not hold with its shrunk counterexample,
+// and returns true if all of them hold.
+//
+//	func TestMaxMonoid(t *testing.T) {
+//		m := functional.NewMonoid(math.MinInt, func(a, b int) int { return max(a, b) })
+//		laws.CheckMonoid(t, m, proptest.Int())
+//	}
+package laws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/proptest"
+)
+
+// check checks a law, reporting it by name if it does not hold.
+func check[T any](t testing.TB, law string, g proptest.Gen[T], prop func(T) bool) bool {
+	t.Helper()
+	if f := proptest.Check(proptest.Config{Seed: proptest.SeedOf(t)}, g, prop); f != nil {
+		t.Errorf("laws: %s does not hold: %s", law, f)
+		return false
+	}
+	return true
+}
+
+// all returns true if all the laws hold, after checking every one of them.
+func all(results ...bool) bool {
+	for _, ok := range results {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// equal is the default equality, reflect.DeepEqual.
+func equal[T any](a, b T) bool {
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package laws
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/proptest"
+)
+
+// errLaw is the error of the failed Results generated by the Result checks.
+var errLaw = errors.New("laws: failed result")
+
+// CheckFunctor checks the functor laws of fmap over the values of gen, comparing them with eq:
+//   - identity: fmap(m, id) == m
+//   - composition: fmap(m, g . f) == fmap(fmap(m, f), g)
+//
+// M is the functor type holding T values, e.g. Optional[T], and f and g are any functions.
+func CheckFunctor[M, T any](t testing.TB, fmap func(M, func(T) T) M, gen proptest.Gen[M], f, g func(T) T, eq func(M, M) bool) bool {
+	t.Helper()
+	return all(
+		check(t, "functor identity", gen, func(m M) bool {
+			return eq(fmap(m, func(x T) T { return x }), m)
+		}),
+		check(t, "functor composition", gen, func(m M) bool {
+			return eq(fmap(m, func(x T) T { return g(f(x)) }), fmap(fmap(m, f), g))
+		}),
+	)
+}
+
+// CheckMonad checks the monad laws of unit and bind, comparing the results with eq:
+//   - left identity: bind(unit(a), f) == f(a)
+//   - right identity: bind(m, unit) == m
+//   - associativity: bind(bind(m, f), g) == bind(m, x -> bind(f(x), g))
+//
+// M is the monad type holding T values, e.g. Optional[T], the values a are drawn from ga,
+// the values m from gm, and f and g are any functions.
+func CheckMonad[M, T any](t testing.TB, unit func(T) M, bind func(M, func(T) M) M,
+	ga proptest.Gen[T], gm proptest.Gen[M], f, g func(T) M, eq func(M, M) bool) bool {
+	t.Helper()
+	return all(
+		check(t, "monad left identity", ga, func(a T) bool {
+			return eq(bind(unit(a), f), f(a))
+		}),
+		check(t, "monad right identity", gm, func(m M) bool {
+			return eq(bind(m, unit), m)
+		}),
+		check(t, "monad associativity", gm, func(m M) bool {
+			return eq(bind(bind(m, f), g), bind(m, func(x T) M { return bind(f(x), g) }))
+		}),
+	)
+}
+
+// optionalEqual returns true if both Optionals are missing, or both hold equal values.
+func optionalEqual[T any](a, b functional.Optional[T]) bool {
+	if a.IsPresent() != b.IsPresent() {
+		return false
+	}
+	return !a.IsPresent() || equal(a.Unwrap(), b.Unwrap())
+}
+
+// resultEqual returns true if both Results hold equal values, or equal errors.
+func resultEqual[T any](a, b functional.Result[T]) bool {
+	if a.IsOk() != b.IsOk() {
+		return false
+	}
+	if a.IsOk() {
+		return equal(a.Value(), b.Value())
+	}
+	return equal(a.Err(), b.Err())
+}
+
+// CheckOptional checks the functor laws of functional.OptionalMap with f and g,
+// and the monad laws of functional.Just and functional.OptionalFlatMap with
+// the Kleisli arrows x -> Just(f(x)) and x -> Nothing, over Optionals of the values of gen.
+func CheckOptional[T any](t testing.TB, gen proptest.Gen[T], f, g func(T) T) bool {
+	t.Helper()
+	gm := proptest.OptionalOf(gen)
+	just := func(x T) functional.Optional[T] { return functional.Just(f(x)) }
+	nothing := func(T) functional.Optional[T] { return functional.Nothing[T]() }
+	return all(
+		CheckFunctor(t, functional.OptionalMap[T, T], gm, f, g, optionalEqual[T]),
+		CheckMonad(t, functional.Just[T], functional.OptionalFlatMap[T, T], gen, gm, just, nothing, optionalEqual[T]),
+		CheckMonad(t, functional.Just[T], functional.OptionalFlatMap[T, T], gen, gm, nothing, just, optionalEqual[T]),
+	)
+}
+
+// CheckResult checks the functor laws of functional.ResultMap with f and g,
+// and the monad laws of functional.Ok and functional.ResultFlatMap with
+// the Kleisli arrows x -> Ok(f(x)) and x -> Err, over Results of the values of gen.
+func CheckResult[T any](t testing.TB, gen proptest.Gen[T], f, g func(T) T) bool {
+	t.Helper()
+	gm := proptest.OneOf(
+		proptest.Map(gen, functional.Ok[T]),
+		proptest.Const(functional.Err[T](errLaw)),
+	)
+	ok := func(x T) functional.Result[T] { return functional.Ok(f(x)) }
+	fail := func(T) functional.Result[T] { return functional.Err[T](errLaw) }
+	return all(
+		CheckFunctor(t, functional.ResultMap[T, T], gm, f, g, resultEqual[T]),
+		CheckMonad(t, functional.Ok[T], functional.ResultFlatMap[T, T], gen, gm, ok, fail, resultEqual[T]),
+		CheckMonad(t, functional.Ok[T], functional.ResultFlatMap[T, T], gen, gm, fail, ok, resultEqual[T]),
+	)
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package laws_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/laws"
+	"github.com/mizumoto-cn/fpkit/proptest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckOptional(t *testing.T) {
+	inc := func(x int) int { return x + 1 }
+	double := func(x int) int { return x * 2 }
+	assert.True(t, laws.CheckOptional(t, proptest.Int(), inc, double))
+	assert.True(t, laws.CheckOptional(t, proptest.String(), strconv.Quote, func(s string) string { return s + s }))
+}
+
+func TestCheckResult(t *testing.T) {
+	inc := func(x int) int { return x + 1 }
+	double := func(x int) int { return x * 2 }
+	assert.True(t, laws.CheckResult(t, proptest.Int(), inc, double))
+}
+
+func TestCheckMonadFailures(t *testing.T) {
+	// the FlatMap method of Optional calls the function on Nothing,
+	// which breaks the right identity law
+	bindMethod := func(m functional.Optional[int], f func(int) functional.Optional[int]) functional.Optional[int] {
+		return m.FlatMap(f)
+	}
+	eq := func(a, b functional.Optional[int]) bool {
+		return a.IsPresent() == b.IsPresent() && (!a.IsPresent() || a.Unwrap() == b.Unwrap())
+	}
+	just := func(x int) functional.Optional[int] { return functional.Just(x + 1) }
+	ok, errs := record(t, func(t testing.TB) bool {
+		return laws.CheckMonad(t, functional.Just[int], bindMethod,
+			proptest.Int(), proptest.OptionalOf(proptest.Int()), just, just, eq)
+	})
+	assert.False(t, ok)
+	if assert.Len(t, errs, 1) {
+		assert.Contains(t, errs[0], "monad right identity")
+	}
+
+	// a map which drops the values breaks the identity law
+	broken := func(m functional.Optional[int], f func(int) int) functional.Optional[int] {
+		return functional.Nothing[int]()
+	}
+	ok, errs = record(t, func(t testing.TB) bool {
+		return laws.CheckFunctor(t, broken, proptest.OptionalOf(proptest.Int()),
+			func(x int) int { return x }, func(x int) int { return x }, eq)
+	})
+	assert.False(t, ok)
+	if assert.Len(t, errs, 1) {
+		assert.Contains(t, errs[0], "functor identity")
+	}
+}
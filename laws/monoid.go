@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package laws
+
+import (
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/proptest"
+)
+
+// CheckMonoid checks the associativity and the identity laws of the Monoid,
+// comparing the values with reflect.DeepEqual.
+func CheckMonoid[T any](t testing.TB, m functional.Monoid[T], g proptest.Gen[T]) bool {
+	t.Helper()
+	return CheckMonoidFunc(t, m, g, equal[T])
+}
+
+// CheckMonoidFunc checks the associativity and the identity laws of the Monoid,
+// comparing the values with eq, e.g. to tolerate rounding errors.
+func CheckMonoidFunc[T any](t testing.TB, m functional.Monoid[T], g proptest.Gen[T], eq func(T, T) bool) bool {
+	t.Helper()
+	return all(
+		check(t, "monoid associativity", proptest.Zip3(g, g, g), func(p functional.Triple[T, T, T]) bool {
+			a, b, c := p.Unpack()
+			return eq(m.Combine(m.Combine(a, b), c), m.Combine(a, m.Combine(b, c)))
+		}),
+		check(t, "monoid left identity", g, func(a T) bool {
+			return eq(m.Combine(m.Empty(), a), a)
+		}),
+		check(t, "monoid right identity", g, func(a T) bool {
+			return eq(m.Combine(a, m.Empty()), a)
+		}),
+	)
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package laws_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/laws"
+	"github.com/mizumoto-cn/fpkit/proptest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckMonoid(t *testing.T) {
+	assert.True(t, laws.CheckMonoid(t, functional.SumMonoid[int](), proptest.Int()))
+	assert.True(t, laws.CheckMonoid(t, functional.ProductMonoid[int](), proptest.Int()))
+	assert.True(t, laws.CheckMonoid(t, functional.StringMonoid(), proptest.String()))
+	assert.True(t, laws.CheckMonoid(t, functional.SliceMonoid[int](), proptest.SliceOf(proptest.Int())))
+	maxMonoid := functional.NewMonoid(math.MinInt, func(a, b int) int { return max(a, b) })
+	assert.True(t, laws.CheckMonoid(t, maxMonoid, proptest.Int()))
+
+	// floating-point addition needs a tolerance
+	approx := func(a, b float64) bool { return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(a)) }
+	assert.True(t, laws.CheckMonoidFunc(t, functional.SumMonoid[float64](), proptest.Range(-1e6, 1e6), approx))
+}
+
+func TestCheckMonoidFailures(t *testing.T) {
+	// subtraction is not associative
+	minus := functional.NewMonoid(0, func(a, b int) int { return a - b })
+	ok, errs := record(t, func(t testing.TB) bool { return laws.CheckMonoid(t, minus, proptest.Int()) })
+	assert.False(t, ok)
+	if assert.Len(t, errs, 2) {
+		assert.Contains(t, errs[0], "monoid associativity")
+		assert.Contains(t, errs[1], "monoid left identity")
+	}
+
+	// a wrong identity element
+	wrongEmpty := functional.NewMonoid(1, func(a, b int) int { return a + b })
+	ok, errs = record(t, func(t testing.TB) bool { return laws.CheckMonoid(t, wrongEmpty, proptest.Int()) })
+	assert.False(t, ok)
+	assert.Len(t, errs, 2)
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package laws
+
+import (
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/proptest"
+)
+
+// permutations returns the 6 orderings of the triple.
+func permutations[T any](p functional.Triple[T, T, T]) [][3]T {
+	a, b, c := p.Unpack()
+	return [][3]T{{a, b, c}, {a, c, b}, {b, a, c}, {b, c, a}, {c, a, b}, {c, b, a}}
+}
+
+// CheckComparator checks that the comparator, meaning "a comes before b", is a strict weak ordering,
+// as sorting and priority queues expect:
+//   - irreflexivity: !cmp(a, a), which a "<=" comparator breaks
+//   - asymmetry: cmp(a, b) implies !cmp(b, a)
+//   - transitivity: cmp(a, b) and cmp(b, c) imply cmp(a, c)
+//   - transitivity of equivalence: a ~ b and b ~ c imply a ~ c,
+//     where a ~ b means neither comes before the other
+func CheckComparator[T any](t testing.TB, cmp functional.ComparatorAny[T], g proptest.Gen[T]) bool {
+	t.Helper()
+	equiv := func(a, b T) bool { return !cmp(a, b) && !cmp(b, a) }
+	triples := proptest.Zip3(g, g, g)
+	return all(
+		check(t, "comparator irreflexivity", g, func(a T) bool {
+			return !cmp(a, a)
+		}),
+		check(t, "comparator asymmetry", proptest.Zip(g, g), func(p functional.Pair[T, T]) bool {
+			a, b := p.Unpack()
+			return !cmp(a, b) || !cmp(b, a)
+		}),
+		check(t, "comparator transitivity", triples, func(p functional.Triple[T, T, T]) bool {
+			for _, x := range permutations(p) {
+				if cmp(x[0], x[1]) && cmp(x[1], x[2]) && !cmp(x[0], x[2]) {
+					return false
+				}
+			}
+			return true
+		}),
+		check(t, "comparator transitivity of equivalence", triples, func(p functional.Triple[T, T, T]) bool {
+			for _, x := range permutations(p) {
+				if equiv(x[0], x[1]) && equiv(x[1], x[2]) && !equiv(x[0], x[2]) {
+					return false
+				}
+			}
+			return true
+		}),
+	)
+}
+
+// CheckOrderedComparator checks that the comparator of an Orderable type is a strict total order:
+// a strict weak ordering, see CheckComparator, where only equal values are equivalent,
+// so that exactly one of cmp(a, b), cmp(b, a) and a == b holds.
+//
+//	laws.CheckOrderedComparator(t, functional.Less[int], proptest.Int())
+func CheckOrderedComparator[T functional.Orderable](t testing.TB, cmp functional.Comparator[T], g proptest.Gen[T]) bool {
+	t.Helper()
+	return all(
+		CheckComparator(t, functional.ComparatorAny[T](cmp), g),
+		check(t, "comparator totality", proptest.Zip(g, g), func(p functional.Pair[T, T]) bool {
+			a, b := p.Unpack()
+			n := 0
+			for _, holds := range []bool{cmp(a, b), cmp(b, a), a == b} {
+				if holds {
+					n++
+				}
+			}
+			return n == 1
+		}),
+	)
+}
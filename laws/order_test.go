@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package laws_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/laws"
+	"github.com/mizumoto-cn/fpkit/proptest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type task struct {
+	Priority int
+	Name     string
+}
+
+func TestCheckComparator(t *testing.T) {
+	byPriority := func(a, b task) bool { return a.Priority < b.Priority }
+	tasks := proptest.Struct[task](
+		proptest.FieldOf("Priority", proptest.Range(0, 5)),
+		proptest.FieldOf("Name", proptest.String()),
+	)
+	assert.True(t, laws.CheckComparator(t, byPriority, tasks))
+	assert.True(t, laws.CheckOrderedComparator(t, functional.Less[int], proptest.Int()))
+	assert.True(t, laws.CheckOrderedComparator(t, functional.Greater[string], proptest.String()))
+	assert.True(t, laws.CheckOrderedComparator(t, functional.Less[float64], proptest.Range(-10.0, 10.0)))
+}
+
+func TestCheckComparatorFailures(t *testing.T) {
+	small := proptest.Range(0, 5)
+
+	// "<=" is not irreflexive nor asymmetric
+	lessOrEqual := func(a, b int) bool { return a <= b }
+	ok, errs := record(t, func(t testing.TB) bool { return laws.CheckComparator(t, lessOrEqual, small) })
+	assert.False(t, ok)
+	joined := strings.Join(errs, "\n")
+	assert.Contains(t, joined, "comparator irreflexivity")
+	assert.Contains(t, joined, "comparator asymmetry")
+
+	// "closer than 2 is equivalent" is not transitive
+	fuzzy := func(a, b int) bool { return a < b-1 }
+	ok, errs = record(t, func(t testing.TB) bool { return laws.CheckComparator(t, fuzzy, small) })
+	assert.False(t, ok)
+	assert.Contains(t, strings.Join(errs, "\n"), "comparator transitivity of equivalence")
+
+	// rock-paper-scissors
+	beats := func(a, b int) bool { return (a+1)%3 == b }
+	ok, errs = record(t, func(t testing.TB) bool { return laws.CheckComparator(t, beats, proptest.Range(0, 2)) })
+	assert.False(t, ok)
+	assert.Contains(t, strings.Join(errs, "\n"), "comparator transitivity")
+
+	// a weak ordering which is not total
+	byTens := func(a, b int) bool { return a/10 < b/10 }
+	assert.True(t, laws.CheckComparator(t, byTens, proptest.Range(0, 100)))
+	ok, errs = record(t, func(t testing.TB) bool {
+		return laws.CheckOrderedComparator(t, byTens, proptest.Range(0, 100))
+	})
+	assert.False(t, ok)
+	assert.Equal(t, 1, len(errs))
+	assert.Contains(t, errs[0], "comparator totality")
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package maps
+
+import "github.com/mizumoto-cn/fpkit/functional"
+
+// Entries returns the key-value pairs of the map.
+// As with ranging over a map, the order of the entries is not specified.
+//
+//	Entries(map[string]int{"a": 1}) // [("a", 1)]
+func Entries[K comparable, V any](m map[K]V) []functional.Pair[K, V] {
+	result := make([]functional.Pair[K, V], 0, len(m))
+	for k, v := range m {
+		result = append(result, functional.PairOf(k, v))
+	}
+	return result
+}
+
+// FromEntries builds a map from key-value pairs.
+// When a key appears more than once, the last value wins.
+//
+//	FromEntries(slice.Zip([]string{"a", "b"}, []int{1, 2})) // map[a:1 b:2]
+func FromEntries[K comparable, V any](entries []functional.Pair[K, V]) map[K]V {
+	result := make(map[K]V, len(entries))
+	for _, e := range entries {
+		k, v := e.Unpack()
+		result[k] = v
+	}
+	return result
+}
+
+// Keys returns the keys of the map, in no specified order.
+func Keys[K comparable, V any](m map[K]V) []K {
+	result := make([]K, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
+
+// Values returns the values of the map, in no specified order.
+func Values[K comparable, V any](m map[K]V) []V {
+	result := make([]V, 0, len(m))
+	for _, v := range m {
+		result = append(result, v)
+	}
+	return result
+}
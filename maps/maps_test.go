@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package maps_test
+
+import (
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/maps"
+	"github.com/mizumoto-cn/fpkit/slice"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntries(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	entries := maps.Entries(m)
+	assert.ElementsMatch(t, []functional.Pair[string, int]{
+		functional.PairOf("a", 1),
+		functional.PairOf("b", 2),
+		functional.PairOf("c", 3),
+	}, entries)
+	assert.Equal(t, m, maps.FromEntries(entries))
+
+	assert.Empty(t, maps.Entries(map[string]int(nil)))
+	assert.Empty(t, maps.FromEntries[string, int](nil))
+}
+
+func TestFromEntries(t *testing.T) {
+	entries := slice.Zip([]string{"a", "b", "a"}, []int{1, 2, 3})
+	assert.Equal(t, map[string]int{"a": 3, "b": 2}, maps.FromEntries(entries))
+}
+
+func TestKeysValues(t *testing.T) {
+	m := map[int]string{1: "one", 2: "two"}
+	assert.ElementsMatch(t, []int{1, 2}, maps.Keys(m))
+	assert.ElementsMatch(t, []string{"one", "two"}, maps.Values(m))
+}
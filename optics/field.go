@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package optics
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/mizumoto-cn/fpkit/internal/err"
+)
+
+// fieldKey identifies a field path of a struct type, looked up as a field of type want.
+type fieldKey struct {
+	typ  reflect.Type
+	path string
+	want reflect.Type
+}
+
+// fieldCache caches the resolved field indexes by fieldKey.
+var fieldCache sync.Map
+
+// resolveField returns the index sequence of the field path of the struct type,
+// checking that it is exported, made of struct values only, and of type A.
+func resolveField(typ reflect.Type, path string, want reflect.Type) ([]int, error) {
+	key := fieldKey{typ: typ, path: path, want: want}
+	if index, ok := fieldCache.Load(key); ok {
+		return index.([]int), nil
+	}
+
+	var index []int
+	cur := typ
+	for _, name := range strings.Split(path, ".") {
+		if cur.Kind() != reflect.Struct {
+			return nil, err.NewInvalidFieldError(typ, path, cur.String()+" is not a struct")
+		}
+		f, ok := cur.FieldByName(name)
+		if !ok {
+			return nil, err.NewInvalidFieldError(typ, path, "no field "+name)
+		}
+		if !f.IsExported() {
+			return nil, err.NewInvalidFieldError(typ, path, "field "+name+" is unexported")
+		}
+		for i := 1; i < len(f.Index); i++ {
+			// promoted through an embedded pointer, which would be shared by the copies
+			if cur.FieldByIndex(f.Index[:i]).Type.Kind() == reflect.Ptr {
+				return nil, err.NewInvalidFieldError(typ, path, "field "+name+" is promoted through a pointer")
+			}
+		}
+		index = append(index, f.Index...)
+		cur = f.Type
+	}
+	if cur != want {
+		return nil, err.NewInvalidFieldError(typ, path, "field is "+cur.String()+", not "+want.String())
+	}
+	fieldCache.Store(key, index)
+	return index, nil
+}
+
+// Field returns the Lens focusing on the field of the struct S at the given path,
+// e.g. "Customer.Address.City". The path is resolved by reflection once, and cached.
+//
+// Every field of the path must be exported, and each but the last one must be a struct,
+// not a pointer to a struct: setting through a pointer would modify the original value.
+// The field must be of type A.
+//
+//	city, err := optics.Field[Order, string]("Customer.Address.City")
+//	order = city.Set(order, "Kyoto")
+//
+// Field is slower than a Lens built by hand with NewLens.
+func Field[S, A any](path string) (Lens[S, A], error) {
+	typ := reflect.TypeOf((*S)(nil)).Elem()
+	index, e := resolveField(typ, path, reflect.TypeOf((*A)(nil)).Elem())
+	if e != nil {
+		return Lens[S, A]{}, e
+	}
+	return Lens[S, A]{
+		get: func(s S) A {
+			return *reflect.ValueOf(&s).Elem().FieldByIndex(index).Addr().Interface().(*A)
+		},
+		set: func(s S, a A) S {
+			// s is a copy, and the path goes through struct values only
+			v := reflect.ValueOf(&s).Elem()
+			v.FieldByIndex(index).Set(reflect.ValueOf(&a).Elem())
+			return s
+		},
+	}, nil
+}
+
+// MustField is like Field but panics if the field path is invalid,
+// to initialize lenses in package variables.
+func MustField[S, A any](path string) Lens[S, A] {
+	l, e := Field[S, A](path)
+	if e != nil {
+		panic(e)
+	}
+	return l
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package optics_test
+
+import (
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/optics"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Base struct {
+	Tag string
+}
+
+type withEmbedded struct {
+	Base
+	Err    error
+	hidden int
+}
+
+type withPtr struct {
+	*Base
+	Next *Address
+}
+
+func TestField(t *testing.T) {
+	o := newOrder()
+	city, e := optics.Field[Order, string]("Customer.Address.City")
+	assert.NoError(t, e)
+	assert.Equal(t, "Tokyo", city.Get(o))
+	o2 := city.Set(o, "Kyoto")
+	assert.Equal(t, "Kyoto", o2.Customer.Address.City)
+	assert.Equal(t, "Tokyo", o.Customer.Address.City)
+
+	// the reflective lens agrees with the hand-written one
+	byHand := optics.Compose(orderCustomer, optics.Compose(customerAddress, addressCity))
+	assert.Equal(t, byHand.Set(o, "Kobe"), city.Set(o, "Kobe"))
+
+	// slices are replaced, not mutated
+	addresses := optics.MustField[Order, []Address]("Customer.Addresses")
+	o3 := addresses.Set(o, nil)
+	assert.Nil(t, o3.Customer.Addresses)
+	assert.Len(t, o.Customer.Addresses, 2)
+
+	// promoted fields and interface fields
+	tag := optics.MustField[withEmbedded, string]("Tag")
+	assert.Equal(t, "x", tag.Set(withEmbedded{}, "x").Base.Tag)
+	errLens := optics.MustField[withEmbedded, error]("Err")
+	assert.Nil(t, errLens.Get(withEmbedded{}))
+	assert.Equal(t, assert.AnError, errLens.Set(withEmbedded{}, assert.AnError).Err)
+}
+
+func TestFieldErrors(t *testing.T) {
+	_, e := optics.Field[Order, string]("Customer.Nope")
+	assert.Error(t, e)
+	_, e = optics.Field[Order, int]("Customer.Name")
+	assert.Error(t, e)
+	_, e = optics.Field[Order, string]("ID.Name")
+	assert.Error(t, e)
+	_, e = optics.Field[withEmbedded, int]("hidden")
+	assert.Error(t, e)
+	_, e = optics.Field[withPtr, string]("Tag")
+	assert.Error(t, e)
+	_, e = optics.Field[withPtr, string]("Next.City")
+	assert.Error(t, e)
+	_, e = optics.Field[int, int]("X")
+	assert.Error(t, e)
+
+	assert.Panics(t, func() { optics.MustField[Order, string]("Nope") })
+}
+
+type cachedField struct {
+	X string
+}
+
+func TestFieldCacheChecksType(t *testing.T) {
+	// the first lookup caches the path, the second one must still check its type
+	x, e := optics.Field[cachedField, string]("X")
+	assert.NoError(t, e)
+	assert.Equal(t, "a", x.Get(cachedField{X: "a"}))
+	_, e = optics.Field[cachedField, int]("X")
+	assert.Error(t, e)
+	assert.Panics(t, func() { optics.MustField[cachedField, int]("X") })
+	x, e = optics.Field[cachedField, string]("X")
+	assert.NoError(t, e)
+	assert.Equal(t, "b", x.Get(cachedField{X: "b"}))
+}
+
+func BenchmarkField(b *testing.B) {
+	city := optics.MustField[Order, string]("Customer.Address.City")
+	o := newOrder()
+	for i := 0; i < b.N; i++ {
+		o = city.Set(o, city.Get(o))
+	}
+}
+
+func BenchmarkLens(b *testing.B) {
+	city := optics.Compose(orderCustomer, optics.Compose(customerAddress, addressCity))
+	o := newOrder()
+	for i := 0; i < b.N; i++ {
+		o = city.Set(o, city.Get(o))
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package optics provides composable getters and setters for immutable updates of nested values.
+//
+//	city := optics.Compose(customerLens, optics.Compose(addressLens, cityLens))
+//	order = city.Set(order, "Kyoto") // returns an updated copy, order is untouched
+package optics
+
+// Lens focuses on a part A of a whole S, which always exists, e.g. a struct field.
+// It is built from a getter and a setter, the setter returning an updated copy of the whole.
+type Lens[S, A any] struct {
+	get func(S) A
+	set func(S, A) S
+}
+
+// NewLens creates a Lens from a getter and a setter.
+// The setter must not modify its argument, but return an updated copy.
+//
+//	cityLens := optics.NewLens(
+//		func(a Address) string { return a.City },
+//		func(a Address, city string) Address { a.City = city; return a },
+//	)
+func NewLens[S, A any](get func(S) A, set func(S, A) S) Lens[S, A] {
+	return Lens[S, A]{get: get, set: set}
+}
+
+// Get returns the part of the whole.
+func (l Lens[S, A]) Get(s S) A {
+	return l.get(s)
+}
+
+// Set returns a copy of the whole with the part replaced.
+func (l Lens[S, A]) Set(s S, a A) S {
+	return l.set(s, a)
+}
+
+// Modify returns a copy of the whole with fn applied to the part.
+func (l Lens[S, A]) Modify(s S, fn func(A) A) S {
+	return l.set(s, fn(l.get(s)))
+}
+
+// AsTraversal returns the Traversal focusing on the single part of the Lens.
+func (l Lens[S, A]) AsTraversal() Traversal[S, A] {
+	return NewTraversal(
+		func(s S) []A { return []A{l.get(s)} },
+		l.Modify,
+	)
+}
+
+// Compose returns the Lens focusing on the part B of the part A of the whole S.
+//
+//	Compose(orderCustomer, customerName).Get(order) // order.Customer.Name
+func Compose[S, A, B any](outer Lens[S, A], inner Lens[A, B]) Lens[S, B] {
+	return Lens[S, B]{
+		get: func(s S) B {
+			return inner.get(outer.get(s))
+		},
+		set: func(s S, b B) S {
+			return outer.set(s, inner.set(outer.get(s), b))
+		},
+	}
+}
+
+// Identity returns the Lens focusing on the whole itself.
+func Identity[S any]() Lens[S, S] {
+	return Lens[S, S]{
+		get: func(s S) S { return s },
+		set: func(_ S, s S) S { return s },
+	}
+}
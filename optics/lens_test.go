@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package optics_test
+
+import (
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/optics"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Address struct {
+	Street string
+	City   string
+}
+
+type Customer struct {
+	Name      string
+	Address   Address
+	Addresses []Address
+}
+
+type Order struct {
+	ID       int
+	Customer Customer
+}
+
+var (
+	orderCustomer = optics.NewLens(
+		func(o Order) Customer { return o.Customer },
+		func(o Order, c Customer) Order { o.Customer = c; return o },
+	)
+	customerAddress = optics.NewLens(
+		func(c Customer) Address { return c.Address },
+		func(c Customer, a Address) Customer { c.Address = a; return c },
+	)
+	customerAddresses = optics.NewLens(
+		func(c Customer) []Address { return c.Addresses },
+		func(c Customer, a []Address) Customer { c.Addresses = a; return c },
+	)
+	addressCity = optics.NewLens(
+		func(a Address) string { return a.City },
+		func(a Address, city string) Address { a.City = city; return a },
+	)
+)
+
+func newOrder() Order {
+	return Order{ID: 1, Customer: Customer{
+		Name:      "Ann",
+		Address:   Address{Street: "Main St", City: "Tokyo"},
+		Addresses: []Address{{City: "Osaka"}, {City: "Nara"}},
+	}}
+}
+
+func TestLens(t *testing.T) {
+	o := newOrder()
+	assert.Equal(t, "Tokyo", addressCity.Get(o.Customer.Address))
+
+	updated := addressCity.Set(o.Customer.Address, "Kyoto")
+	assert.Equal(t, "Kyoto", updated.City)
+	assert.Equal(t, "Main St", updated.Street)
+	assert.Equal(t, "Tokyo", o.Customer.Address.City)
+
+	upper := addressCity.Modify(o.Customer.Address, func(s string) string { return s + "!" })
+	assert.Equal(t, "Tokyo!", upper.City)
+}
+
+func TestCompose(t *testing.T) {
+	o := newOrder()
+	city := optics.Compose(orderCustomer, optics.Compose(customerAddress, addressCity))
+	assert.Equal(t, "Tokyo", city.Get(o))
+
+	o2 := city.Set(o, "Kyoto")
+	assert.Equal(t, "Kyoto", o2.Customer.Address.City)
+	assert.Equal(t, "Tokyo", o.Customer.Address.City)
+	assert.Equal(t, o.ID, o2.ID)
+	assert.Equal(t, o.Customer.Name, o2.Customer.Name)
+
+	// composition is associative
+	city2 := optics.Compose(optics.Compose(orderCustomer, customerAddress), addressCity)
+	assert.Equal(t, city.Set(o, "Kobe"), city2.Set(o, "Kobe"))
+
+	// identity is neutral
+	assert.Equal(t, city.Get(o), optics.Compose(optics.Identity[Order](), city).Get(o))
+	assert.Equal(t, city.Set(o, "Kobe"), optics.Compose(city, optics.Identity[string]()).Set(o, "Kobe"))
+}
+
+func TestLensAsTraversal(t *testing.T) {
+	o := newOrder()
+	tr := orderCustomer.AsTraversal()
+	assert.Len(t, tr.GetAll(o), 1)
+	assert.Equal(t, "Bob", tr.Modify(o, func(c Customer) Customer { c.Name = "Bob"; return c }).Customer.Name)
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package optics
+
+import "github.com/mizumoto-cn/fpkit/functional"
+
+// Prism focuses on a case A of a whole S, which may or may not match,
+// e.g. a variant of a sum type, or the value of an Optional.
+// Unlike a Lens, a Prism can build a whole from a part.
+type Prism[S, A any] struct {
+	preview func(S) functional.Optional[A]
+	review  func(A) S
+}
+
+// NewPrism creates a Prism from a matcher, returning Nothing if the whole does not match,
+// and a builder of the whole from the part.
+func NewPrism[S, A any](preview func(S) functional.Optional[A], review func(A) S) Prism[S, A] {
+	return Prism[S, A]{preview: preview, review: review}
+}
+
+// Preview returns the part if the whole matches, Nothing otherwise.
+func (p Prism[S, A]) Preview(s S) functional.Optional[A] {
+	return p.preview(s)
+}
+
+// Review builds the whole from the part.
+func (p Prism[S, A]) Review(a A) S {
+	return p.review(a)
+}
+
+// Modify returns the whole built from fn applied to the part if the whole matches,
+// the whole unchanged otherwise.
+func (p Prism[S, A]) Modify(s S, fn func(A) A) S {
+	if a := p.preview(s); a.IsPresent() {
+		return p.review(fn(a.Unwrap()))
+	}
+	return s
+}
+
+// Set returns the whole built from the part if the whole matches, the whole unchanged otherwise.
+func (p Prism[S, A]) Set(s S, a A) S {
+	return p.Modify(s, func(A) A { return a })
+}
+
+// AsTraversal returns the Traversal focusing on the part if the whole matches, on nothing otherwise.
+func (p Prism[S, A]) AsTraversal() Traversal[S, A] {
+	return NewTraversal(
+		func(s S) []A {
+			if a := p.preview(s); a.IsPresent() {
+				return []A{a.Unwrap()}
+			}
+			return nil
+		},
+		p.Modify,
+	)
+}
+
+// ComposePrism returns the Prism focusing on the case B of the case A of the whole S.
+func ComposePrism[S, A, B any](outer Prism[S, A], inner Prism[A, B]) Prism[S, B] {
+	return Prism[S, B]{
+		preview: func(s S) functional.Optional[B] {
+			if a := outer.preview(s); a.IsPresent() {
+				return inner.preview(a.Unwrap())
+			}
+			return functional.Nothing[B]()
+		},
+		review: func(b B) S {
+			return outer.review(inner.review(b))
+		},
+	}
+}
+
+// Some returns the Prism focusing on the value of an Optional, if present.
+//
+//	optics.Some[int]().Modify(functional.Just(1), inc) // Just(2)
+//	optics.Some[int]().Modify(functional.Nothing[int](), inc) // Nothing
+func Some[A any]() Prism[functional.Optional[A], A] {
+	return Prism[functional.Optional[A], A]{
+		preview: func(o functional.Optional[A]) functional.Optional[A] {
+			if o.IsPresent() {
+				return o
+			}
+			return functional.Nothing[A]()
+		},
+		review: functional.Just[A],
+	}
+}
+
+// Case returns the Prism focusing on the variant A of the sum type S,
+// where S is an interface implemented by A.
+//
+//	type Shape interface{ Area() float64 }
+//	circle := optics.Case[Shape, Circle]()
+//	circle.Preview(Circle{R: 1}) // Just(Circle{R: 1})
+//	circle.Preview(Square{A: 1}) // Nothing
+//
+// It panics when reviewing if A does not implement S.
+func Case[S, A any]() Prism[S, A] {
+	return Prism[S, A]{
+		preview: func(s S) functional.Optional[A] {
+			if a, ok := any(s).(A); ok {
+				return functional.Just(a)
+			}
+			return functional.Nothing[A]()
+		},
+		review: func(a A) S {
+			return any(a).(S)
+		},
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package optics_test
+
+import (
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/optics"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Shape interface {
+	Area() float64
+}
+
+type Circle struct{ R float64 }
+
+func (c Circle) Area() float64 { return 3 * c.R * c.R }
+
+type Square struct{ A float64 }
+
+func (s Square) Area() float64 { return s.A * s.A }
+
+func TestSome(t *testing.T) {
+	p := optics.Some[int]()
+	inc := func(x int) int { return x + 1 }
+	assert.Equal(t, 2, p.Modify(functional.Just(1), inc).Unwrap())
+	assert.False(t, p.Modify(functional.Nothing[int](), inc).IsPresent())
+	assert.Equal(t, 5, p.Set(functional.Just(1), 5).Unwrap())
+	assert.False(t, p.Set(functional.Nothing[int](), 5).IsPresent())
+	assert.Equal(t, 3, p.Review(3).Unwrap())
+	assert.False(t, p.Preview(functional.Nothing[int]()).IsPresent())
+	assert.Equal(t, 1, p.Preview(functional.Just(1)).Unwrap())
+}
+
+func TestCase(t *testing.T) {
+	circle := optics.Case[Shape, Circle]()
+	assert.Equal(t, Circle{R: 1}, circle.Preview(Circle{R: 1}).Unwrap())
+	assert.False(t, circle.Preview(Square{A: 1}).IsPresent())
+
+	double := func(c Circle) Circle { return Circle{R: c.R * 2} }
+	assert.Equal(t, Shape(Circle{R: 2}), circle.Modify(Circle{R: 1}, double))
+	assert.Equal(t, Shape(Square{A: 1}), circle.Modify(Square{A: 1}, double))
+	assert.Equal(t, Shape(Circle{R: 3}), circle.Review(Circle{R: 3}))
+
+	// prisms over Optionals of sum types
+	p := optics.ComposePrism(optics.Some[Shape](), circle)
+	assert.Equal(t, Circle{R: 1}, p.Preview(functional.Just[Shape](Circle{R: 1})).Unwrap())
+	assert.False(t, p.Preview(functional.Just[Shape](Square{A: 1})).IsPresent())
+	assert.False(t, p.Preview(functional.Nothing[Shape]()).IsPresent())
+	assert.Equal(t, Shape(Circle{R: 4}), p.Review(Circle{R: 4}).Unwrap())
+}
+
+func TestPrismAsTraversal(t *testing.T) {
+	shapes := []Shape{Circle{R: 1}, Square{A: 2}, Circle{R: 3}}
+	radii := optics.ComposeTraversal(optics.Each[Shape](), optics.Case[Shape, Circle]().AsTraversal())
+	assert.Equal(t, []Circle{{R: 1}, {R: 3}}, radii.GetAll(shapes))
+
+	grown := radii.Modify(shapes, func(c Circle) Circle { return Circle{R: c.R + 1} })
+	assert.Equal(t, []Shape{Circle{R: 2}, Square{A: 2}, Circle{R: 4}}, grown)
+	assert.Equal(t, Shape(Circle{R: 1}), shapes[0])
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package optics
+
+// Traversal focuses on any number of parts A of a whole S, e.g. the elements of a slice.
+type Traversal[S, A any] struct {
+	getAll func(S) []A
+	modify func(S, func(A) A) S
+}
+
+// NewTraversal creates a Traversal from a getter of all the parts,
+// and a function returning a copy of the whole with fn applied to each part.
+func NewTraversal[S, A any](getAll func(S) []A, modify func(S, func(A) A) S) Traversal[S, A] {
+	return Traversal[S, A]{getAll: getAll, modify: modify}
+}
+
+// GetAll returns the parts of the whole.
+func (t Traversal[S, A]) GetAll(s S) []A {
+	return t.getAll(s)
+}
+
+// Modify returns a copy of the whole with fn applied to each part.
+func (t Traversal[S, A]) Modify(s S, fn func(A) A) S {
+	return t.modify(s, fn)
+}
+
+// Set returns a copy of the whole with every part replaced by a.
+func (t Traversal[S, A]) Set(s S, a A) S {
+	return t.modify(s, func(A) A { return a })
+}
+
+// ComposeTraversal returns the Traversal focusing on the parts B of each part A of the whole S.
+// Lenses and Prisms can be composed with it through their AsTraversal method.
+//
+//	// the cities of all the addresses of a customer
+//	optics.ComposeTraversal(addresses.AsTraversal(), optics.ComposeTraversal(optics.Each[Address](), city.AsTraversal()))
+func ComposeTraversal[S, A, B any](outer Traversal[S, A], inner Traversal[A, B]) Traversal[S, B] {
+	return Traversal[S, B]{
+		getAll: func(s S) []B {
+			var result []B
+			for _, a := range outer.getAll(s) {
+				result = append(result, inner.getAll(a)...)
+			}
+			return result
+		},
+		modify: func(s S, fn func(B) B) S {
+			return outer.modify(s, func(a A) A {
+				return inner.modify(a, fn)
+			})
+		},
+	}
+}
+
+// Each returns the Traversal focusing on the elements of a slice.
+// Modify returns a new slice, the original one is untouched.
+func Each[A any]() Traversal[[]A, A] {
+	return Traversal[[]A, A]{
+		getAll: func(s []A) []A {
+			return append([]A(nil), s...)
+		},
+		modify: func(s []A, fn func(A) A) []A {
+			if s == nil {
+				return nil
+			}
+			result := make([]A, len(s))
+			for i, a := range s {
+				result[i] = fn(a)
+			}
+			return result
+		},
+	}
+}
+
+// Filtered returns the Traversal focusing on the elements of a slice satisfying the predicate.
+func Filtered[A any](pred func(A) bool) Traversal[[]A, A] {
+	return Traversal[[]A, A]{
+		getAll: func(s []A) []A {
+			var result []A
+			for _, a := range s {
+				if pred(a) {
+					result = append(result, a)
+				}
+			}
+			return result
+		},
+		modify: func(s []A, fn func(A) A) []A {
+			return Each[A]().modify(s, func(a A) A {
+				if pred(a) {
+					return fn(a)
+				}
+				return a
+			})
+		},
+	}
+}
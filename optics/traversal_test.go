@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package optics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/optics"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEach(t *testing.T) {
+	s := []int{1, 2, 3}
+	each := optics.Each[int]()
+	assert.Equal(t, []int{1, 2, 3}, each.GetAll(s))
+	assert.Equal(t, []int{2, 4, 6}, each.Modify(s, func(x int) int { return x * 2 }))
+	assert.Equal(t, []int{0, 0, 0}, each.Set(s, 0))
+	assert.Equal(t, []int{1, 2, 3}, s)
+	assert.Nil(t, each.Modify(nil, func(x int) int { return x }))
+}
+
+func TestFiltered(t *testing.T) {
+	even := optics.Filtered(func(x int) bool { return x%2 == 0 })
+	s := []int{1, 2, 3, 4}
+	assert.Equal(t, []int{2, 4}, even.GetAll(s))
+	assert.Equal(t, []int{1, 0, 3, 0}, even.Set(s, 0))
+	assert.Equal(t, []int{1, 2, 3, 4}, s)
+}
+
+func TestComposeTraversal(t *testing.T) {
+	o := newOrder()
+	cities := optics.ComposeTraversal(
+		optics.Compose(orderCustomer, customerAddresses).AsTraversal(),
+		optics.ComposeTraversal(optics.Each[Address](), addressCity.AsTraversal()),
+	)
+	assert.Equal(t, []string{"Osaka", "Nara"}, cities.GetAll(o))
+
+	o2 := cities.Modify(o, strings.ToUpper)
+	assert.Equal(t, []string{"OSAKA", "NARA"}, cities.GetAll(o2))
+	assert.Equal(t, "Osaka", o.Customer.Addresses[0].City)
+	assert.Equal(t, "Tokyo", o2.Customer.Address.City)
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package persistent
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+// seed is the seed of the default hash function.
+var seed = maphash.MakeSeed()
+
+// mix scrambles the bits of an integer, so that close keys spread over the trie.
+func mix(x uint64) uint64 {
+	// splitmix64 finalizer
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// Hash is the default hash function of a PMap. Keys equal under == hash the same.
+// It is fast for strings, booleans and numbers. Other comparable types, like structs,
+// arrays and interfaces, are walked by reflection following the rules of ==,
+// so a dedicated hash function given to NewPMapWithHasher is faster for them.
+func Hash[K comparable](k K) uint64 {
+	switch v := any(k).(type) {
+	case string:
+		return maphash.String(seed, v)
+	case int:
+		return mix(uint64(v))
+	case int8:
+		return mix(uint64(v))
+	case int16:
+		return mix(uint64(v))
+	case int32:
+		return mix(uint64(v))
+	case int64:
+		return mix(uint64(v))
+	case uint:
+		return mix(uint64(v))
+	case uint8:
+		return mix(uint64(v))
+	case uint16:
+		return mix(uint64(v))
+	case uint32:
+		return mix(uint64(v))
+	case uint64:
+		return mix(v)
+	case uintptr:
+		return mix(uint64(v))
+	case float32:
+		return hashFloat(float64(v))
+	case float64:
+		return hashFloat(v)
+	case bool:
+		if v {
+			return mix(1)
+		}
+		return mix(0)
+	default:
+		var h maphash.Hash
+		h.SetSeed(seed)
+		hashValue(&h, reflect.ValueOf(&k).Elem())
+		return h.Sum64()
+	}
+}
+
+// hashValue writes v to h so that values equal under == write the same bytes.
+func hashValue(h *maphash.Hash, v reflect.Value) {
+	var buf [8]byte
+	word := func(x uint64) {
+		binary.LittleEndian.PutUint64(buf[:], x)
+		_, _ = h.Write(buf[:])
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			word(1)
+		} else {
+			word(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		word(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		word(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		word(hashFloat(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		word(hashFloat(real(c)))
+		word(hashFloat(imag(c)))
+	case reflect.String:
+		word(uint64(v.Len()))
+		_, _ = h.WriteString(v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		word(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			word(0)
+			return
+		}
+		hashValue(h, v.Elem())
+	case reflect.Array:
+		for i := range v.Len() {
+			hashValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := range v.NumField() {
+			// == ignores blank fields
+			if t.Field(i).Name != "_" {
+				hashValue(h, v.Field(i))
+			}
+		}
+	}
+}
+
+// hashFloat hashes a float, 0 and -0 being equal they hash the same.
+func hashFloat(f float64) uint64 {
+	if f == 0 {
+		return 0
+	}
+	return mix(math.Float64bits(f))
+}
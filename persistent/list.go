@@ -0,0 +1,232 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package persistent
+
+import "github.com/mizumoto-cn/fpkit/functional"
+
+// cell is a cell of a List. It also records the length of the list starting from it.
+type cell[T any] struct {
+	value T
+	next  *cell[T]
+	size  int
+}
+
+// List is an immutable singly-linked list. Cons, Head and Tail are O(1), and lists
+// share their tails: consing onto a list never copies it, and old lists stay valid.
+// Unlike slice.Iterator.Tail, the tail of a List can never be modified through another list.
+//
+// The zero value is an empty list ready to use.
+//
+//	xs := persistent.ListOf(2, 3)
+//	ys := persistent.Cons(1, xs) // [1, 2, 3], sharing xs
+//	if x, rest, ok := ys.Uncons(); ok {
+//		// x == 1, rest == xs
+//	}
+type List[T any] struct {
+	head *cell[T]
+}
+
+// Nil returns the empty list.
+func Nil[T any]() List[T] {
+	return List[T]{}
+}
+
+// Cons returns the list with the value in front of the tail, in O(1).
+func Cons[T any](head T, tail List[T]) List[T] {
+	return List[T]{&cell[T]{value: head, next: tail.head, size: tail.Len() + 1}}
+}
+
+// ListOf returns the list of the given values.
+func ListOf[T any](v ...T) List[T] {
+	return ListFromSlice(v)
+}
+
+// ListFromSlice returns the list of the elements of the slice.
+func ListFromSlice[T any](s []T) List[T] {
+	l := Nil[T]()
+	for i := len(s) - 1; i >= 0; i-- {
+		l = Cons(s[i], l)
+	}
+	return l
+}
+
+// IsEmpty returns true if the list has no element.
+func (l List[T]) IsEmpty() bool {
+	return l.head == nil
+}
+
+// Len returns the number of elements, in O(1).
+func (l List[T]) Len() int {
+	if l.head == nil {
+		return 0
+	}
+	return l.head.size
+}
+
+// Head returns the first element, or Nothing if the list is empty.
+func (l List[T]) Head() functional.Optional[T] {
+	if l.head == nil {
+		return functional.Nothing[T]()
+	}
+	return functional.Just(l.head.value)
+}
+
+// Tail returns the list without its first element, the tail of the empty list is empty.
+func (l List[T]) Tail() List[T] {
+	if l.head == nil {
+		return l
+	}
+	return List[T]{l.head.next}
+}
+
+// Uncons returns the head and the tail of the list, and false if the list is empty.
+//
+//	for x, rest, ok := l.Uncons(); ok; x, rest, ok = rest.Uncons() {
+//		...
+//	}
+func (l List[T]) Uncons() (T, List[T], bool) {
+	if l.head == nil {
+		var zero T
+		return zero, l, false
+	}
+	return l.head.value, List[T]{l.head.next}, true
+}
+
+// Range calls fn on each element in order, until it returns false.
+func (l List[T]) Range(fn func(T) bool) {
+	for c := l.head; c != nil; c = c.next {
+		if !fn(c.value) {
+			return
+		}
+	}
+}
+
+// Slice returns the elements in a new slice,
+// e.g. to use the list with the slice functions like functional.Foldl.
+func (l List[T]) Slice() []T {
+	s := make([]T, 0, l.Len())
+	l.Range(func(v T) bool {
+		s = append(s, v)
+		return true
+	})
+	return s
+}
+
+// Reverse returns the list in reverse order.
+func (l List[T]) Reverse() List[T] {
+	return ListFoldl(l, func(acc List[T], v T) List[T] {
+		return Cons(v, acc)
+	}, Nil[T]())
+}
+
+// Append returns the list followed by the other list.
+// The elements of l are copied, the other list is shared.
+func (l List[T]) Append(other List[T]) List[T] {
+	return ListFoldr(l, func(acc List[T], v T) List[T] {
+		return Cons(v, acc)
+	}, other)
+}
+
+// Filter returns the list of the elements satisfying the predicate.
+// It shares the longest tail of the list whose elements all satisfy it.
+func (l List[T]) Filter(fn func(T) bool) List[T] {
+	var kept []T
+	// the elements kept before the last dropped one, and the tail after it
+	copied, shared := 0, l.head
+	for c := l.head; c != nil; c = c.next {
+		if fn(c.value) {
+			kept = append(kept, c.value)
+		} else {
+			copied, shared = len(kept), c.next
+		}
+	}
+	result := List[T]{shared}
+	for i := copied - 1; i >= 0; i-- {
+		result = Cons(kept[i], result)
+	}
+	return result
+}
+
+// Take returns the list of the first n elements.
+func (l List[T]) Take(n int) List[T] {
+	if n >= l.Len() {
+		return l
+	}
+	s := make([]T, 0, max(n, 0))
+	for c := l.head; c != nil && len(s) < n; c = c.next {
+		s = append(s, c.value)
+	}
+	return ListFromSlice(s)
+}
+
+// Drop returns the list without its first n elements, sharing the rest.
+func (l List[T]) Drop(n int) List[T] {
+	c := l.head
+	for ; c != nil && n > 0; n-- {
+		c = c.next
+	}
+	return List[T]{c}
+}
+
+// ListMap returns the list of the results of fn applied to each element.
+func ListMap[T, U any](l List[T], fn func(T) U) List[U] {
+	return ListFoldr(l, func(acc List[U], v T) List[U] {
+		return Cons(fn(v), acc)
+	}, Nil[U]())
+}
+
+// ListFoldl folds the list from the left, with the same signature as functional.Foldl.
+//
+//	ListFoldl(ListOf(1, 2, 3), func(acc, x int) int { return acc*10 + x }, 0) // 123
+func ListFoldl[T, U any](l List[T], fn func(U, T) U, init U) U {
+	for c := l.head; c != nil; c = c.next {
+		init = fn(init, c.value)
+	}
+	return init
+}
+
+// ListFoldr folds the list from the right, with the same signature as functional.Foldr.
+// It does not recurse, so it is safe on long lists.
+//
+//	ListFoldr(ListOf(1, 2, 3), func(acc, x int) int { return acc*10 + x }, 0) // 321
+func ListFoldr[T, U any](l List[T], fn func(U, T) U, init U) U {
+	return functional.Foldr(l.Slice(), fn, init)
+}
+
+// ListEqual returns true if the lists have the same elements in the same order.
+func ListEqual[T comparable](a, b List[T]) bool {
+	return ListEqualFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// ListEqualFunc returns true if the lists have equal elements in the same order, according to eq.
+func ListEqualFunc[T any](a, b List[T], eq func(T, T) bool) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for x, y := a.head, b.head; x != nil; x, y = x.next, y.next {
+		if x == y {
+			// the rest is shared
+			return true
+		}
+		if !eq(x.value, y.value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package persistent_test
+
+import (
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/persistent"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListConsHeadTail(t *testing.T) {
+	var empty persistent.List[int]
+	assert.True(t, empty.IsEmpty())
+	assert.Equal(t, 0, empty.Len())
+	assert.False(t, empty.Head().IsPresent())
+	assert.True(t, empty.Tail().IsEmpty())
+	_, _, ok := empty.Uncons()
+	assert.False(t, ok)
+
+	xs := persistent.ListOf(2, 3)
+	ys := persistent.Cons(1, xs)
+	assert.Equal(t, 3, ys.Len())
+	assert.Equal(t, 1, ys.Head().Unwrap())
+	assert.True(t, persistent.ListEqual(xs, ys.Tail()))
+	assert.Equal(t, []int{2, 3}, xs.Slice())
+
+	// consing onto the same list twice gives independent lists sharing the tail
+	zs := persistent.Cons(0, xs)
+	assert.Equal(t, []int{1, 2, 3}, ys.Slice())
+	assert.Equal(t, []int{0, 2, 3}, zs.Slice())
+
+	var got []int
+	for x, rest, ok := ys.Uncons(); ok; x, rest, ok = rest.Uncons() {
+		got = append(got, x)
+	}
+	assert.Equal(t, []int{1, 2, 3}, got)
+}
+
+func TestListTransformations(t *testing.T) {
+	l := persistent.ListFromSlice([]int{1, 2, 3, 4, 5})
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, l.Reverse().Slice())
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, l.Append(persistent.ListOf(6, 7)).Slice())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, l.Append(persistent.Nil[int]()).Slice())
+	assert.Equal(t, []int{2, 4}, l.Filter(func(x int) bool { return x%2 == 0 }).Slice())
+	assert.Equal(t, []int{1, 2, 4, 5}, l.Filter(func(x int) bool { return x != 3 }).Slice())
+	assert.Equal(t, []int{}, l.Filter(func(int) bool { return false }).Slice())
+	assert.True(t, persistent.ListEqual(l, l.Filter(func(int) bool { return true })))
+	assert.Equal(t, []int{1, 2}, l.Take(2).Slice())
+	assert.Equal(t, []int{}, l.Take(-1).Slice())
+	assert.Equal(t, 5, l.Take(10).Len())
+	assert.Equal(t, []int{4, 5}, l.Drop(3).Slice())
+	assert.True(t, l.Drop(10).IsEmpty())
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"},
+		persistent.ListMap(l, func(x int) string { return string(rune('0' + x)) }).Slice())
+	// the original is untouched
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, l.Slice())
+}
+
+func TestListFolds(t *testing.T) {
+	l := persistent.ListOf(1, 2, 3)
+	digits := func(acc, x int) int { return acc*10 + x }
+	assert.Equal(t, 123, persistent.ListFoldl(l, digits, 0))
+	assert.Equal(t, 321, persistent.ListFoldr(l, digits, 0))
+	// folds agree with the slice ones
+	assert.Equal(t, functional.Foldl(l.Slice(), digits, 0), persistent.ListFoldl(l, digits, 0))
+	assert.Equal(t, functional.Foldr(l.Slice(), digits, 0), persistent.ListFoldr(l, digits, 0))
+
+	// long lists do not blow the stack
+	long := persistent.Nil[int]()
+	for i := 0; i < 1_000_000; i++ {
+		long = persistent.Cons(1, long)
+	}
+	sum := func(acc, x int) int { return acc + x }
+	assert.Equal(t, 1_000_000, persistent.ListFoldr(long, sum, 0))
+	assert.Equal(t, 1_000_000, persistent.ListMap(long, func(x int) int { return x }).Len())
+}
+
+func TestListEqual(t *testing.T) {
+	assert.True(t, persistent.ListEqual(persistent.ListOf(1, 2), persistent.ListOf(1, 2)))
+	assert.False(t, persistent.ListEqual(persistent.ListOf(1, 2), persistent.ListOf(2, 1)))
+	assert.False(t, persistent.ListEqual(persistent.ListOf(1, 2), persistent.ListOf(1)))
+	assert.True(t, persistent.ListEqual(persistent.Nil[int](), persistent.ListOf[int]()))
+
+	sameLen := func(a, b string) bool { return len(a) == len(b) }
+	assert.True(t, persistent.ListEqualFunc(persistent.ListOf("a", "bb"), persistent.ListOf("x", "yy"), sameLen))
+}
@@ -0,0 +1,396 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package persistent
+
+import (
+	"math/bits"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+)
+
+// hashBits is the number of bits of a hash,
+// below that depth the keys with the same hash are kept in collision nodes.
+const hashBits = 64
+
+// hentry is an entry of a HAMT node: either a key-value pair or a subtree.
+type hentry[K comparable, V any] struct {
+	hash  uint64
+	key   K
+	value V
+	sub   *hnode[K, V]
+}
+
+// hnode is a bitmap indexed node of a HAMT.
+// Bit i of the bitmap is set if the node has an entry for the hash bits i at its depth,
+// and the entries are stored in the order of their bits.
+// Below hashBits, the node is a collision node: the bitmap is unused and the entries are unordered.
+type hnode[K comparable, V any] struct {
+	bitmap  uint32
+	entries []hentry[K, V]
+	owner   *owner
+}
+
+// editable returns the node itself if o owns it, otherwise a copy owned by o.
+func (n *hnode[K, V]) editable(o *owner) *hnode[K, V] {
+	if o != nil && n.owner == o {
+		return n
+	}
+	entries := make([]hentry[K, V], len(n.entries), len(n.entries)+1)
+	copy(entries, n.entries)
+	return &hnode[K, V]{bitmap: n.bitmap, entries: entries, owner: o}
+}
+
+// removeAt removes the entry at index i, the node must be editable.
+func (n *hnode[K, V]) removeAt(i int) {
+	last := len(n.entries) - 1
+	copy(n.entries[i:], n.entries[i+1:])
+	n.entries[last] = hentry[K, V]{}
+	n.entries = n.entries[:last]
+}
+
+// insertAt inserts the entry at index i, the node must be editable.
+func (n *hnode[K, V]) insertAt(i int, e hentry[K, V]) {
+	n.entries = append(n.entries, hentry[K, V]{})
+	copy(n.entries[i+1:], n.entries[i:])
+	n.entries[i] = e
+}
+
+// locate returns the bit of the hash at the given depth and the index of its entry.
+func (n *hnode[K, V]) locate(hash uint64, shift uint) (uint32, int) {
+	bit := uint32(1) << ((hash >> shift) & mask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// get looks the key up in the subtree of n.
+func (n *hnode[K, V]) get(hash uint64, shift uint, key K) (V, bool) {
+	for n != nil {
+		if shift >= hashBits {
+			for _, e := range n.entries {
+				if e.key == key {
+					return e.value, true
+				}
+			}
+			break
+		}
+		bit, i := n.locate(hash, shift)
+		if n.bitmap&bit == 0 {
+			break
+		}
+		e := n.entries[i]
+		if e.sub == nil {
+			if e.key == key {
+				return e.value, true
+			}
+			break
+		}
+		n, shift = e.sub, shift+levelBits
+	}
+	var zero V
+	return zero, false
+}
+
+// put sets the entry in the subtree of n, editing in place the nodes o owns.
+// It returns the new subtree, and true if the key was not present.
+func (n *hnode[K, V]) put(o *owner, shift uint, e hentry[K, V]) (*hnode[K, V], bool) {
+	if shift >= hashBits {
+		for i := range n.entries {
+			if n.entries[i].key == e.key {
+				n = n.editable(o)
+				n.entries[i].value = e.value
+				return n, false
+			}
+		}
+		n = n.editable(o)
+		n.entries = append(n.entries, e)
+		return n, true
+	}
+
+	bit, i := n.locate(e.hash, shift)
+	if n.bitmap&bit == 0 {
+		n = n.editable(o)
+		n.insertAt(i, e)
+		n.bitmap |= bit
+		return n, true
+	}
+	cur := n.entries[i]
+	switch {
+	case cur.sub != nil:
+		sub, added := cur.sub.put(o, shift+levelBits, e)
+		if sub != cur.sub {
+			n = n.editable(o)
+			n.entries[i].sub = sub
+		}
+		return n, added
+	case cur.key == e.key:
+		n = n.editable(o)
+		n.entries[i].value = e.value
+		return n, false
+	default:
+		n = n.editable(o)
+		n.entries[i] = hentry[K, V]{sub: merge(o, shift+levelBits, cur, e)}
+		return n, true
+	}
+}
+
+// merge returns a subtree holding the two entries, whose hashes are the same down to shift.
+func merge[K comparable, V any](o *owner, shift uint, a, b hentry[K, V]) *hnode[K, V] {
+	if shift >= hashBits {
+		return &hnode[K, V]{entries: []hentry[K, V]{a, b}, owner: o}
+	}
+	ai, bi := (a.hash>>shift)&mask, (b.hash>>shift)&mask
+	if ai == bi {
+		return &hnode[K, V]{
+			bitmap:  1 << ai,
+			entries: []hentry[K, V]{{sub: merge(o, shift+levelBits, a, b)}},
+			owner:   o,
+		}
+	}
+	if ai > bi {
+		a, b = b, a
+	}
+	return &hnode[K, V]{bitmap: 1<<ai | 1<<bi, entries: []hentry[K, V]{a, b}, owner: o}
+}
+
+// remove deletes the key from the subtree of n, editing in place the nodes o owns.
+// It returns the new subtree, and true if the key was present.
+func (n *hnode[K, V]) remove(o *owner, hash uint64, shift uint, key K) (*hnode[K, V], bool) {
+	if shift >= hashBits {
+		for i := range n.entries {
+			if n.entries[i].key == key {
+				n = n.editable(o)
+				n.removeAt(i)
+				return n, true
+			}
+		}
+		return n, false
+	}
+
+	bit, i := n.locate(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	cur := n.entries[i]
+	if cur.sub == nil {
+		if cur.key != key {
+			return n, false
+		}
+		n = n.editable(o)
+		n.removeAt(i)
+		n.bitmap &^= bit
+		return n, true
+	}
+
+	sub, removed := cur.sub.remove(o, hash, shift+levelBits, key)
+	if !removed {
+		return n, false
+	}
+	n = n.editable(o)
+	switch {
+	case len(sub.entries) == 0:
+		n.removeAt(i)
+		n.bitmap &^= bit
+	case len(sub.entries) == 1 && sub.entries[0].sub == nil:
+		// pull a lone key-value pair up, to keep the trie shallow
+		n.entries[i] = sub.entries[0]
+	default:
+		n.entries[i].sub = sub
+	}
+	return n, true
+}
+
+// each calls fn on each key-value pair of the subtree of n, until it returns false.
+func (n *hnode[K, V]) each(fn func(K, V) bool) bool {
+	for _, e := range n.entries {
+		if e.sub != nil {
+			if !e.sub.each(fn) {
+				return false
+			}
+		} else if !fn(e.key, e.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// PMap is a persistent hash map: every update returns a new version,
+// which shares most of its structure with the old one. Old versions are never modified,
+// so they can be read concurrently without copying or locking.
+//
+// It is a hash array mapped trie (HAMT), so Get, Put and Delete are O(log32 n).
+// The zero value is an empty map ready to use, hashing the keys with Hash.
+//
+//	m1 := persistent.NewPMap[string, int]().Put("a", 1)
+//	m2 := m1.Put("b", 2)
+//	m1.Len() // 1
+//	m2.Len() // 2
+type PMap[K comparable, V any] struct {
+	root   *hnode[K, V]
+	size   int
+	hasher func(K) uint64
+}
+
+// NewPMap creates an empty PMap hashing the keys with Hash.
+func NewPMap[K comparable, V any]() PMap[K, V] {
+	return PMap[K, V]{}
+}
+
+// NewPMapWithHasher creates an empty PMap hashing the keys with the given function.
+// Equal keys must have equal hashes.
+func NewPMapWithHasher[K comparable, V any](hasher func(K) uint64) PMap[K, V] {
+	return PMap[K, V]{hasher: hasher}
+}
+
+// FromMap creates a PMap holding the entries of the map.
+func FromMap[K comparable, V any](m map[K]V) PMap[K, V] {
+	t := NewPMap[K, V]().Transient()
+	for k, v := range m {
+		t.Put(k, v)
+	}
+	return t.Persistent()
+}
+
+// hash returns the hash of the key.
+func (m PMap[K, V]) hash(k K) uint64 {
+	if m.hasher == nil {
+		return Hash(k)
+	}
+	return m.hasher(k)
+}
+
+// Len returns the number of entries.
+func (m PMap[K, V]) Len() int {
+	return m.size
+}
+
+// Get returns the value of the key and true, or false if the key is absent.
+func (m PMap[K, V]) Get(k K) (V, bool) {
+	return m.root.get(m.hash(k), 0, k)
+}
+
+// Has returns true if the key is present.
+func (m PMap[K, V]) Has(k K) bool {
+	_, ok := m.Get(k)
+	return ok
+}
+
+// Put returns a new PMap with the key set to the value.
+func (m PMap[K, V]) Put(k K, v V) PMap[K, V] {
+	return m.put(nil, k, v)
+}
+
+// put sets the key to the value, editing in place the nodes o owns.
+func (m PMap[K, V]) put(o *owner, k K, v V) PMap[K, V] {
+	root := m.root
+	if root == nil {
+		root = &hnode[K, V]{owner: o}
+	}
+	root, added := root.put(o, 0, hentry[K, V]{hash: m.hash(k), key: k, value: v})
+	m.root = root
+	if added {
+		m.size++
+	}
+	return m
+}
+
+// Delete returns a new PMap without the key.
+func (m PMap[K, V]) Delete(k K) PMap[K, V] {
+	return m.delete(nil, k)
+}
+
+// delete removes the key, editing in place the nodes o owns.
+func (m PMap[K, V]) delete(o *owner, k K) PMap[K, V] {
+	if m.root == nil {
+		return m
+	}
+	root, removed := m.root.remove(o, m.hash(k), 0, k)
+	if removed {
+		m.root = root
+		m.size--
+	}
+	return m
+}
+
+// Range calls fn on each entry, in no specified order, until it returns false.
+func (m PMap[K, V]) Range(fn func(K, V) bool) {
+	if m.root != nil {
+		m.root.each(fn)
+	}
+}
+
+// ToMap returns the entries in a new map.
+func (m PMap[K, V]) ToMap() map[K]V {
+	result := make(map[K]V, m.size)
+	m.Range(func(k K, v V) bool {
+		result[k] = v
+		return true
+	})
+	return result
+}
+
+// Entries returns the key-value pairs, in no specified order.
+func (m PMap[K, V]) Entries() []functional.Pair[K, V] {
+	result := make([]functional.Pair[K, V], 0, m.size)
+	m.Range(func(k K, v V) bool {
+		result = append(result, functional.PairOf(k, v))
+		return true
+	})
+	return result
+}
+
+// Transient returns a mutable copy of the PMap, for batch updates.
+// It shares the structure of the PMap, and copies a node only the first time it edits it.
+func (m PMap[K, V]) Transient() *TMap[K, V] {
+	return &TMap[K, V]{m: m, owner: &owner{}}
+}
+
+// TMap is the transient builder of a PMap, see PMap.Transient.
+// It is not thread-safe.
+type TMap[K comparable, V any] struct {
+	m     PMap[K, V]
+	owner *owner
+}
+
+// Len returns the number of entries.
+func (t *TMap[K, V]) Len() int {
+	return t.m.size
+}
+
+// Get returns the value of the key and true, or false if the key is absent.
+func (t *TMap[K, V]) Get(k K) (V, bool) {
+	return t.m.Get(k)
+}
+
+// Put sets the key to the value.
+func (t *TMap[K, V]) Put(k K, v V) *TMap[K, V] {
+	t.m = t.m.put(t.owner, k, v)
+	return t
+}
+
+// Delete removes the key.
+func (t *TMap[K, V]) Delete(k K) *TMap[K, V] {
+	t.m = t.m.delete(t.owner, k)
+	return t
+}
+
+// Persistent returns the PMap built so far.
+// The transient can still be used afterwards: it will copy the nodes it shares with the result.
+func (t *TMap[K, V]) Persistent() PMap[K, V] {
+	t.owner = &owner{}
+	return t.m
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package persistent_test
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/persistent"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPMapZeroValue(t *testing.T) {
+	var m persistent.PMap[string, int]
+	assert.Equal(t, 0, m.Len())
+	_, ok := m.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, m.Delete("a").Len())
+
+	m = m.Put("a", 1)
+	v, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+}
+
+func TestPMapPutGetDelete(t *testing.T) {
+	n := 5000
+	m := persistent.NewPMap[int, string]()
+	for i := 0; i < n; i++ {
+		m = m.Put(i, fmt.Sprint(i))
+	}
+	assert.Equal(t, n, m.Len())
+	for i := 0; i < n; i++ {
+		v, ok := m.Get(i)
+		if !assert.True(t, ok) || !assert.Equal(t, fmt.Sprint(i), v) {
+			return
+		}
+	}
+	assert.False(t, m.Has(n))
+
+	// overwriting keeps the size
+	m = m.Put(0, "zero")
+	assert.Equal(t, n, m.Len())
+	v, _ := m.Get(0)
+	assert.Equal(t, "zero", v)
+
+	for i := 0; i < n; i += 2 {
+		m = m.Delete(i)
+	}
+	assert.Equal(t, n/2, m.Len())
+	for i := 0; i < n; i++ {
+		assert.Equal(t, i%2 == 1, m.Has(i))
+	}
+	// deleting an absent key is a no-op
+	assert.Equal(t, n/2, m.Delete(0).Len())
+
+	for i := 1; i < n; i += 2 {
+		m = m.Delete(i)
+	}
+	assert.Equal(t, 0, m.Len())
+	assert.Empty(t, m.ToMap())
+}
+
+func TestPMapImmutability(t *testing.T) {
+	m1 := persistent.FromMap(map[string]int{"a": 1, "b": 2})
+	m2 := m1.Put("c", 3)
+	m3 := m2.Put("a", 10).Delete("b")
+
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, m1.ToMap())
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, m2.ToMap())
+	assert.Equal(t, map[string]int{"a": 10, "c": 3}, m3.ToMap())
+}
+
+func TestPMapCollisions(t *testing.T) {
+	// every key collides, and half of them share the first levels of the trie
+	hasher := func(k int) uint64 { return uint64(k % 2) }
+	m := persistent.NewPMapWithHasher[int, int](hasher)
+	for i := 0; i < 100; i++ {
+		m = m.Put(i, i*i)
+	}
+	assert.Equal(t, 100, m.Len())
+	for i := 0; i < 100; i++ {
+		v, ok := m.Get(i)
+		assert.True(t, ok)
+		assert.Equal(t, i*i, v)
+	}
+	old := m
+	for i := 0; i < 100; i += 3 {
+		m = m.Delete(i)
+	}
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, i%3 != 0, m.Has(i))
+		assert.True(t, old.Has(i))
+	}
+	for i := 0; i < 100; i++ {
+		m = m.Delete(i)
+	}
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestPMapRangeEntries(t *testing.T) {
+	m := persistent.FromMap(map[int]int{1: 10, 2: 20, 3: 30})
+	entries := m.Entries()
+	sort.Slice(entries, func(i, j int) bool { return entries[i].First() < entries[j].First() })
+	assert.Equal(t, []functional.Pair[int, int]{
+		functional.PairOf(1, 10), functional.PairOf(2, 20), functional.PairOf(3, 30),
+	}, entries)
+
+	count := 0
+	m.Range(func(int, int) bool {
+		count++
+		return false
+	})
+	assert.Equal(t, 1, count)
+}
+
+func TestTMap(t *testing.T) {
+	base := persistent.FromMap(map[int]int{1: 1, 2: 2})
+	tr := base.Transient()
+	for i := 3; i <= 1000; i++ {
+		tr.Put(i, i)
+	}
+	tr.Delete(1).Put(2, 20)
+	assert.Equal(t, 999, tr.Len())
+	v, ok := tr.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, 20, v)
+
+	m := tr.Persistent()
+	assert.Equal(t, map[int]int{1: 1, 2: 2}, base.ToMap())
+
+	// editing the transient after Persistent does not leak into the result
+	tr.Put(2, 200).Delete(3).Put(1001, 1001)
+	v, _ = m.Get(2)
+	assert.Equal(t, 20, v)
+	assert.True(t, m.Has(3))
+	assert.False(t, m.Has(1001))
+	assert.Equal(t, 999, m.Len())
+	assert.Equal(t, 999, tr.Len())
+}
+
+func TestHash(t *testing.T) {
+	assert.Equal(t, persistent.Hash("abc"), persistent.Hash("abc"))
+	assert.NotEqual(t, persistent.Hash(1), persistent.Hash(2))
+	assert.Equal(t, persistent.Hash(0.0), persistent.Hash(math.Copysign(0, -1)))
+
+	type point struct{ X, Y int }
+	assert.Equal(t, persistent.Hash(point{1, 2}), persistent.Hash(point{1, 2}))
+	m := persistent.NewPMap[point, string]().Put(point{1, 2}, "a")
+	v, ok := m.Get(point{1, 2})
+	assert.True(t, ok)
+	assert.Equal(t, "a", v)
+}
+
+func TestHashEqualKeys(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	type sample struct {
+		F   float64
+		C   complex128
+		A   [2]float32
+		I   any
+		P   *int
+		_   int
+		Tag string
+	}
+	p := new(int)
+	a := sample{F: 0, C: complex(0, 0), A: [2]float32{0, 1}, I: 0.0, P: p, Tag: "x"}
+	b := sample{F: negZero, C: complex(negZero, negZero), A: [2]float32{float32(negZero), 1}, I: negZero, P: p, Tag: "x"}
+	assert.True(t, a == b)
+	assert.Equal(t, persistent.Hash(a), persistent.Hash(b))
+
+	m := persistent.NewPMap[sample, int]().Put(a, 1)
+	v, ok := m.Get(b)
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	// named basic types and interface keys follow == as well
+	type id string
+	assert.Equal(t, persistent.Hash(id("a")), persistent.Hash(id("a")))
+	assert.Equal(t, persistent.Hash[any](0.0), persistent.Hash[any](negZero))
+	assert.NotEqual(t, persistent.Hash(sample{Tag: "x"}), persistent.Hash(sample{Tag: "y"}))
+}
@@ -0,0 +1,322 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package persistent
+
+import "github.com/mizumoto-cn/fpkit/internal/err"
+
+const (
+	levelBits = 5
+	width     = 1 << levelBits
+	mask      = width - 1
+)
+
+// owner marks the nodes a transient may edit in place.
+// It has a field so that every owner has its own address.
+type owner struct {
+	_ byte
+}
+
+// vnode is a node of the trie of a PVector.
+// Internal nodes hold children, leaves hold values.
+type vnode[T any] struct {
+	children []*vnode[T]
+	values   []T
+	owner    *owner
+}
+
+// clone returns a copy of the node owned by o.
+func (n *vnode[T]) clone(o *owner) *vnode[T] {
+	c := &vnode[T]{owner: o}
+	if n.children != nil {
+		c.children = make([]*vnode[T], width)
+		copy(c.children, n.children)
+	}
+	if n.values != nil {
+		c.values = make([]T, len(n.values), width)
+		copy(c.values, n.values)
+	}
+	return c
+}
+
+// editable returns the node itself if o owns it, otherwise a copy owned by o.
+func (n *vnode[T]) editable(o *owner) *vnode[T] {
+	if o != nil && n.owner == o {
+		return n
+	}
+	return n.clone(o)
+}
+
+// PVector is a persistent vector: every update returns a new version,
+// which shares most of its structure with the old one. Old versions are never modified,
+// so they can be read concurrently without copying or locking.
+//
+// It is a 32-way trie with a tail buffer, so Get, Set and Append are O(log32 n),
+// which is at most 7 steps for a billion elements, and Append is O(1) most of the time.
+// The zero value is an empty vector ready to use.
+//
+//	v1 := persistent.NewPVector[int]().Append(1).Append(2)
+//	v2, _ := v1.Set(0, 10)
+//	v1.Slice() // [1, 2]
+//	v2.Slice() // [10, 2]
+type PVector[T any] struct {
+	cnt   int
+	shift uint
+	root  *vnode[T]
+	tail  []T
+}
+
+// NewPVector creates an empty PVector.
+func NewPVector[T any]() PVector[T] {
+	return PVector[T]{}
+}
+
+// PVectorOf creates a PVector holding the given values.
+func PVectorOf[T any](v ...T) PVector[T] {
+	return FromSlice(v)
+}
+
+// FromSlice creates a PVector holding the elements of the slice.
+func FromSlice[T any](s []T) PVector[T] {
+	t := NewPVector[T]().Transient()
+	for _, v := range s {
+		t.Append(v)
+	}
+	return t.Persistent()
+}
+
+// Len returns the number of elements.
+func (v PVector[T]) Len() int {
+	return v.cnt
+}
+
+// tailOffset returns the index of the first element in the tail.
+func (v PVector[T]) tailOffset() int {
+	if v.cnt < width {
+		return 0
+	}
+	return ((v.cnt - 1) >> levelBits) << levelBits
+}
+
+// leafFor returns the values of the leaf, or the tail, holding the element at index i.
+func (v PVector[T]) leafFor(i int) []T {
+	if i >= v.tailOffset() {
+		return v.tail
+	}
+	n := v.root
+	for level := v.shift; level > 0; level -= levelBits {
+		n = n.children[(i>>level)&mask]
+	}
+	return n.values
+}
+
+// Get returns the element at index i.
+func (v PVector[T]) Get(i int) (T, error) {
+	if i < 0 || i >= v.cnt {
+		var zero T
+		return zero, err.NewIndexOutOfRangeError(i, v.cnt)
+	}
+	return v.leafFor(i)[i&mask], nil
+}
+
+// Set returns a new PVector with the element at index i replaced.
+func (v PVector[T]) Set(i int, value T) (PVector[T], error) {
+	if i < 0 || i >= v.cnt {
+		return v, err.NewIndexOutOfRangeError(i, v.cnt)
+	}
+	return v.set(nil, i, value), nil
+}
+
+// set replaces the element at index i, editing in place the nodes o owns.
+func (v PVector[T]) set(o *owner, i int, value T) PVector[T] {
+	if i >= v.tailOffset() {
+		if o == nil {
+			tail := make([]T, len(v.tail), width)
+			copy(tail, v.tail)
+			v.tail = tail
+		}
+		v.tail[i&mask] = value
+		return v
+	}
+	v.root = doSet(o, v.shift, v.root, i, value)
+	return v
+}
+
+// doSet replaces the element at index i in the subtree of n.
+func doSet[T any](o *owner, level uint, n *vnode[T], i int, value T) *vnode[T] {
+	n = n.editable(o)
+	if level == 0 {
+		n.values[i&mask] = value
+		return n
+	}
+	sub := (i >> level) & mask
+	n.children[sub] = doSet(o, level-levelBits, n.children[sub], i, value)
+	return n
+}
+
+// Append returns a new PVector with the value added at the end.
+func (v PVector[T]) Append(value T) PVector[T] {
+	return v.append(nil, value)
+}
+
+// append adds the value at the end, editing in place the nodes o owns.
+// A transient owns its tail, so it may append to it in place.
+func (v PVector[T]) append(o *owner, value T) PVector[T] {
+	if v.cnt-v.tailOffset() < width {
+		if o == nil {
+			tail := make([]T, len(v.tail), len(v.tail)+1)
+			copy(tail, v.tail)
+			v.tail = tail
+		}
+		v.tail = append(v.tail, value)
+		v.cnt++
+		return v
+	}
+
+	// the tail is full, push it into the trie
+	leaf := &vnode[T]{values: v.tail, owner: o}
+	if v.root == nil {
+		v.root = &vnode[T]{children: make([]*vnode[T], width), owner: o}
+		v.shift = levelBits
+	}
+	if (v.cnt >> levelBits) > (1 << v.shift) {
+		// the trie is full, grow it by one level
+		root := &vnode[T]{children: make([]*vnode[T], width), owner: o}
+		root.children[0] = v.root
+		root.children[1] = newPath(o, v.shift, leaf)
+		v.root = root
+		v.shift += levelBits
+	} else {
+		v.root = pushTail(o, v.cnt, v.shift, v.root, leaf)
+	}
+	v.tail = make([]T, 1, width)
+	v.tail[0] = value
+	v.cnt++
+	return v
+}
+
+// pushTail inserts the leaf as the last one of the subtree of parent.
+func pushTail[T any](o *owner, cnt int, level uint, parent, leaf *vnode[T]) *vnode[T] {
+	n := parent.editable(o)
+	sub := ((cnt - 1) >> level) & mask
+	if level == levelBits {
+		n.children[sub] = leaf
+	} else if child := parent.children[sub]; child != nil {
+		n.children[sub] = pushTail(o, cnt, level-levelBits, child, leaf)
+	} else {
+		n.children[sub] = newPath(o, level-levelBits, leaf)
+	}
+	return n
+}
+
+// newPath returns a branch of single-child nodes from the level down to the leaf.
+func newPath[T any](o *owner, level uint, leaf *vnode[T]) *vnode[T] {
+	if level == 0 {
+		return leaf
+	}
+	n := &vnode[T]{children: make([]*vnode[T], width), owner: o}
+	n.children[0] = newPath(o, level-levelBits, leaf)
+	return n
+}
+
+// Range calls fn on each element in order, until it returns false.
+func (v PVector[T]) Range(fn func(i int, value T) bool) {
+	for i := 0; i < v.cnt; i += width {
+		leaf := v.leafFor(i)
+		for j, value := range leaf {
+			if !fn(i+j, value) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns the elements in a new slice.
+func (v PVector[T]) Slice() []T {
+	s := make([]T, 0, v.cnt)
+	v.Range(func(_ int, value T) bool {
+		s = append(s, value)
+		return true
+	})
+	return s
+}
+
+// Transient returns a mutable copy of the PVector, for batch updates.
+// It shares the structure of the PVector, and copies a node only the first time it edits it.
+//
+//	t := v.Transient()
+//	for _, x := range xs {
+//		t.Append(x)
+//	}
+//	v = t.Persistent()
+func (v PVector[T]) Transient() *TVector[T] {
+	return &TVector[T]{v: v, owner: &owner{}, ownsTail: false}
+}
+
+// TVector is the transient builder of a PVector, see PVector.Transient.
+// It is not thread-safe.
+type TVector[T any] struct {
+	v        PVector[T]
+	owner    *owner
+	ownsTail bool
+}
+
+// ensureTail makes sure the transient may edit its tail in place.
+func (t *TVector[T]) ensureTail() {
+	if !t.ownsTail {
+		tail := make([]T, len(t.v.tail), width)
+		copy(tail, t.v.tail)
+		t.v.tail = tail
+		t.ownsTail = true
+	}
+}
+
+// Len returns the number of elements.
+func (t *TVector[T]) Len() int {
+	return t.v.cnt
+}
+
+// Get returns the element at index i.
+func (t *TVector[T]) Get(i int) (T, error) {
+	return t.v.Get(i)
+}
+
+// Append adds the value at the end.
+func (t *TVector[T]) Append(value T) *TVector[T] {
+	t.ensureTail()
+	t.v = t.v.append(t.owner, value)
+	return t
+}
+
+// Set replaces the element at index i.
+func (t *TVector[T]) Set(i int, value T) error {
+	if i < 0 || i >= t.v.cnt {
+		return err.NewIndexOutOfRangeError(i, t.v.cnt)
+	}
+	t.ensureTail()
+	t.v = t.v.set(t.owner, i, value)
+	return nil
+}
+
+// Persistent returns the PVector built so far.
+// The transient can still be used afterwards: it will copy the nodes it shares with the result.
+func (t *TVector[T]) Persistent() PVector[T] {
+	t.owner = &owner{}
+	t.ownsTail = false
+	return t.v
+}
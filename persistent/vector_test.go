@@ -0,0 +1,174 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package persistent_test
+
+import (
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/persistent"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func seq(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+func TestPVectorZeroValue(t *testing.T) {
+	var v persistent.PVector[int]
+	assert.Equal(t, 0, v.Len())
+	assert.Equal(t, []int{}, v.Slice())
+	_, e := v.Get(0)
+	assert.Error(t, e)
+
+	v = v.Append(1)
+	assert.Equal(t, []int{1}, v.Slice())
+}
+
+func TestPVectorAppendGet(t *testing.T) {
+	// cover the tail, a one-level, a two-level and a three-level trie
+	for _, n := range []int{1, 31, 32, 33, 64, 1024, 1025, 1056, 32768, 32800, 40000} {
+		v := persistent.NewPVector[int]()
+		for i := 0; i < n; i++ {
+			v = v.Append(i)
+		}
+		assert.Equal(t, n, v.Len())
+		for i := 0; i < n; i++ {
+			got, e := v.Get(i)
+			if !assert.NoError(t, e) || !assert.Equal(t, i, got, "n=%d", n) {
+				return
+			}
+		}
+		assert.Equal(t, seq(n), v.Slice())
+	}
+}
+
+func TestPVectorGetOutOfRange(t *testing.T) {
+	v := persistent.PVectorOf(1, 2, 3)
+	for _, i := range []int{-1, 3, 100} {
+		_, e := v.Get(i)
+		assert.Error(t, e)
+		_, e = v.Set(i, 0)
+		assert.Error(t, e)
+	}
+}
+
+func TestPVectorImmutability(t *testing.T) {
+	v1 := persistent.FromSlice(seq(2000))
+	v2, e := v1.Set(0, -1)
+	assert.NoError(t, e)
+	v3, e := v2.Set(1999, -1)
+	assert.NoError(t, e)
+	v4 := v3.Append(2000)
+
+	got, _ := v1.Get(0)
+	assert.Equal(t, 0, got)
+	got, _ = v2.Get(0)
+	assert.Equal(t, -1, got)
+	got, _ = v2.Get(1999)
+	assert.Equal(t, 1999, got)
+	got, _ = v3.Get(1999)
+	assert.Equal(t, -1, got)
+	assert.Equal(t, seq(2000), v1.Slice())
+	assert.Equal(t, 2000, v3.Len())
+	assert.Equal(t, 2001, v4.Len())
+
+	// appending to the same version twice gives independent versions
+	a := v1.Append(1)
+	b := v1.Append(2)
+	got, _ = a.Get(2000)
+	assert.Equal(t, 1, got)
+	got, _ = b.Get(2000)
+	assert.Equal(t, 2, got)
+}
+
+func TestPVectorSetEverywhere(t *testing.T) {
+	n := 1100
+	v := persistent.FromSlice(seq(n))
+	for i := 0; i < n; i++ {
+		v, _ = v.Set(i, i*2)
+	}
+	for i := 0; i < n; i++ {
+		got, _ := v.Get(i)
+		assert.Equal(t, i*2, got)
+	}
+}
+
+func TestPVectorRange(t *testing.T) {
+	v := persistent.FromSlice(seq(100))
+	sum, last := 0, 0
+	v.Range(func(i, value int) bool {
+		assert.Equal(t, i, value)
+		sum += value
+		last = i
+		return i < 49
+	})
+	assert.Equal(t, 49, last)
+	assert.Equal(t, 49*50/2, sum)
+}
+
+func TestTVector(t *testing.T) {
+	base := persistent.FromSlice(seq(100))
+	tr := base.Transient()
+	for i := 100; i < 5000; i++ {
+		tr.Append(i)
+	}
+	assert.NoError(t, tr.Set(0, -1))
+	assert.NoError(t, tr.Set(4999, -1))
+	assert.Error(t, tr.Set(5000, 0))
+	assert.Equal(t, 5000, tr.Len())
+	got, _ := tr.Get(4999)
+	assert.Equal(t, -1, got)
+
+	v := tr.Persistent()
+	// the base is untouched
+	assert.Equal(t, seq(100), base.Slice())
+
+	// editing the transient after Persistent does not leak into the result
+	assert.NoError(t, tr.Set(1, -1))
+	tr.Append(5000)
+	got, _ = v.Get(1)
+	assert.Equal(t, 1, got)
+	got, _ = v.Get(0)
+	assert.Equal(t, -1, got)
+	assert.Equal(t, 5000, v.Len())
+	assert.Equal(t, 5001, tr.Persistent().Len())
+}
+
+func TestPVectorConcurrentReads(t *testing.T) {
+	v := persistent.FromSlice(seq(10000))
+	done := make(chan struct{})
+	for g := 0; g < 4; g++ {
+		go func(g int) {
+			defer func() { done <- struct{}{} }()
+			w := v
+			for i := 0; i < 1000; i++ {
+				w, _ = w.Set(i, g)
+			}
+		}(g)
+	}
+	for g := 0; g < 4; g++ {
+		<-done
+	}
+	assert.Equal(t, seq(10000), v.Slice())
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package pipeline runs typed multi-stage pipelines, whose stages are connected
+// by bounded queue.ArrayBlockingQueue buffers, so that a slow stage slows down the upstream ones
+// instead of letting the buffers grow.
+//
+//	p := pipeline.New()
+//	lines := pipeline.From(p, "read", files)
+//	parsed := pipeline.Then(lines, "parse", parse, pipeline.WithParallelism(8), pipeline.WithOrdered())
+//	pipeline.Sink(parsed, "store", store, pipeline.WithErrorPolicy(pipeline.Skip))
+//	err := p.Run(ctx)
+//
+// Each stage runs fn on its workers, and reports its throughput and latency in Stats.
+// Run returns when every item went through, when a FailFast stage fails, or when the context ends.
+// Stop drains the pipeline gracefully: the sources stop, and the items in flight go through.
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrorPolicy is what a stage does with an item its function fails on.
+type ErrorPolicy int
+
+const (
+	// FailFast stops the whole pipeline, Run returning the error.
+	FailFast ErrorPolicy = iota
+	// Skip drops the item, counting it in the Errors of the stage.
+	Skip
+	// SendToDeadLetter drops the item and records it as a DeadLetter of the pipeline.
+	SendToDeadLetter
+)
+
+// DeadLetter is an item a SendToDeadLetter stage failed on.
+type DeadLetter struct {
+	Stage string
+	Item  any
+	Err   error
+}
+
+// StageError is the error of an item in a stage.
+type StageError struct {
+	Stage string
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("pipeline: stage %s: %v", e.Stage, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// ErrAlreadyRun is returned by Run when the pipeline has already been run.
+var ErrAlreadyRun = errors.New("pipeline: already run")
+
+// Option configures a Pipeline.
+type Option func(*Pipeline)
+
+// WithDeadLetter sets a handler called with each dead letter, from the goroutine of its stage.
+// The dead letters are recorded in DeadLetters as well.
+func WithDeadLetter(handler func(DeadLetter)) Option {
+	return func(p *Pipeline) {
+		p.onDeadLetter = handler
+	}
+}
+
+// runner is a stage, as seen by the pipeline.
+type runner interface {
+	start(ctx context.Context, wg *sync.WaitGroup)
+	validate() error
+	snapshot() StageStats
+}
+
+// Pipeline is a set of connected stages, see the package documentation.
+type Pipeline struct {
+	stages       []runner
+	onDeadLetter func(DeadLetter)
+	buildErr     error
+
+	mu          sync.Mutex
+	deadLetters []DeadLetter
+	err         error
+	cancel      context.CancelFunc
+	running     bool
+	stopOnce    sync.Once
+	stop        chan struct{}
+}
+
+// New creates an empty Pipeline.
+func New(opts ...Option) *Pipeline {
+	p := &Pipeline{stop: make(chan struct{})}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// add registers a stage.
+func (p *Pipeline) add(r runner) {
+	p.stages = append(p.stages, r)
+}
+
+// buildError records the first error in the construction of the pipeline, returned by Run.
+func (p *Pipeline) buildError(e error) {
+	if p.buildErr == nil {
+		p.buildErr = e
+	}
+}
+
+// fail stops the pipeline with the error, the first one being returned by Run.
+func (p *Pipeline) fail(e error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err == nil {
+		p.err = e
+		p.cancel()
+	}
+}
+
+// deadLetter records a dead letter.
+func (p *Pipeline) deadLetter(d DeadLetter) {
+	p.mu.Lock()
+	p.deadLetters = append(p.deadLetters, d)
+	p.mu.Unlock()
+	if p.onDeadLetter != nil {
+		p.onDeadLetter(d)
+	}
+}
+
+// stopped returns a channel closed when Stop is called.
+func (p *Pipeline) stopped() <-chan struct{} {
+	return p.stop
+}
+
+// Run runs the pipeline until every item went through every stage, and returns nil,
+// or until a FailFast stage fails, and returns its *StageError,
+// or until the context ends, and returns the error of the context.
+// A pipeline can be run once.
+func (p *Pipeline) Run(ctx context.Context) error {
+	if p.buildErr != nil {
+		return p.buildErr
+	}
+	for _, s := range p.stages {
+		if e := s.validate(); e != nil {
+			return e
+		}
+	}
+	p.mu.Lock()
+	if p.running {
+		p.mu.Unlock()
+		return ErrAlreadyRun
+	}
+	p.running = true
+	ctx, p.cancel = context.WithCancel(ctx)
+	p.mu.Unlock()
+	defer p.cancel()
+
+	var wg sync.WaitGroup
+	for _, s := range p.stages {
+		s.start(ctx, &wg)
+	}
+	wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	return ctx.Err()
+}
+
+// Stop drains the pipeline gracefully: the sources stop producing,
+// and Run returns once the items already produced went through every stage.
+func (p *Pipeline) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
+}
+
+// DeadLetters returns the dead letters recorded so far.
+func (p *Pipeline) DeadLetters() []DeadLetter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]DeadLetter(nil), p.deadLetters...)
+}
+
+// Stats returns the statistics of the stages, in the order they were added.
+func (p *Pipeline) Stats() []StageStats {
+	result := make([]StageStats, len(p.stages))
+	for i, s := range p.stages {
+		result[i] = s.snapshot()
+	}
+	return result
+}
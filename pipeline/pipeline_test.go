@@ -0,0 +1,230 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package pipeline_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/pipeline"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// collector is a sink recording the items.
+type collector[T any] struct {
+	mu    sync.Mutex
+	items []T
+}
+
+func (c *collector[T]) add(_ context.Context, v T) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = append(c.items, v)
+	return nil
+}
+
+func (c *collector[T]) get() []T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]T(nil), c.items...)
+}
+
+func seq(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+var errOdd = errors.New("odd")
+
+func failOnOdd(_ context.Context, x int) (int, error) {
+	if x%2 == 1 {
+		return 0, errOdd
+	}
+	return x, nil
+}
+
+func TestRun(t *testing.T) {
+	p := pipeline.New()
+	numbers := pipeline.From(p, "numbers", seq(100))
+	strs := pipeline.Then(numbers, "itoa", func(_ context.Context, x int) (string, error) {
+		return strconv.Itoa(x), nil
+	})
+	var c collector[string]
+	pipeline.Sink(strs, "collect", c.add)
+
+	assert.NoError(t, p.Run(context.Background()))
+	assert.Len(t, c.get(), 100)
+	assert.Equal(t, "99", c.get()[99])
+	assert.ErrorIs(t, p.Run(context.Background()), pipeline.ErrAlreadyRun)
+}
+
+func TestBuildErrors(t *testing.T) {
+	p := pipeline.New()
+	pipeline.From(p, "dangling", seq(3))
+	assert.ErrorContains(t, p.Run(context.Background()), "dangling is not consumed")
+
+	p = pipeline.New()
+	numbers := pipeline.From(p, "numbers", seq(3))
+	pipeline.Sink(numbers, "a", func(context.Context, int) error { return nil })
+	pipeline.Sink(numbers, "b", func(context.Context, int) error { return nil })
+	assert.ErrorContains(t, p.Run(context.Background()), "consumed by both a and b")
+}
+
+func TestFailFast(t *testing.T) {
+	p := pipeline.New()
+	numbers := pipeline.From(p, "numbers", seq(1000))
+	checked := pipeline.Then(numbers, "check", failOnOdd, pipeline.WithParallelism(4))
+	pipeline.Sink(checked, "discard", func(context.Context, int) error { return nil })
+
+	e := p.Run(context.Background())
+	var stageErr *pipeline.StageError
+	if assert.ErrorAs(t, e, &stageErr) {
+		assert.Equal(t, "check", stageErr.Stage)
+	}
+	assert.ErrorIs(t, e, errOdd)
+	assert.Less(t, p.Stats()[0].Out, uint64(1000))
+}
+
+func TestSkipAndDeadLetter(t *testing.T) {
+	var handled atomic.Int32
+	p := pipeline.New(pipeline.WithDeadLetter(func(d pipeline.DeadLetter) {
+		handled.Add(1)
+	}))
+	numbers := pipeline.From(p, "numbers", seq(10))
+	evens := pipeline.Then(numbers, "evens", failOnOdd, pipeline.WithErrorPolicy(pipeline.Skip))
+	small := pipeline.Then(evens, "small", func(_ context.Context, x int) (int, error) {
+		if x > 4 {
+			return 0, errors.New("too big")
+		}
+		return x, nil
+	}, pipeline.WithErrorPolicy(pipeline.SendToDeadLetter), pipeline.WithParallelism(2), pipeline.WithOrdered())
+	var c collector[int]
+	pipeline.Sink(small, "collect", c.add)
+
+	assert.NoError(t, p.Run(context.Background()))
+	assert.Equal(t, []int{0, 2, 4}, c.get())
+
+	stats := p.Stats()
+	assert.Equal(t, uint64(5), stats[1].Errors)
+	assert.Equal(t, uint64(5), stats[1].Out)
+	assert.Equal(t, uint64(2), stats[2].Errors)
+
+	letters := p.DeadLetters()
+	assert.Len(t, letters, 2)
+	assert.Equal(t, int32(2), handled.Load())
+	for _, d := range letters {
+		assert.Equal(t, "small", d.Stage)
+		assert.Contains(t, []any{6, 8}, d.Item)
+		assert.EqualError(t, d.Err, "too big")
+	}
+}
+
+func TestPanic(t *testing.T) {
+	p := pipeline.New()
+	numbers := pipeline.From(p, "numbers", seq(3))
+	pipeline.Sink(numbers, "boom", func(context.Context, int) error { panic("boom") })
+	var panicErr *pipeline.PanicError
+	if assert.ErrorAs(t, p.Run(context.Background()), &panicErr) {
+		assert.Equal(t, "boom", panicErr.Value)
+		assert.NotEmpty(t, panicErr.Stack)
+	}
+}
+
+// infinite adds a source of the natural numbers.
+func infinite(p *pipeline.Pipeline) *pipeline.Stage[int] {
+	return pipeline.Generate(p, "naturals", func(ctx context.Context, emit func(int) bool) error {
+		for i := 0; emit(i); i++ {
+		}
+		return nil
+	})
+}
+
+func TestCancel(t *testing.T) {
+	p := pipeline.New()
+	doubled := pipeline.Then(infinite(p), "double", func(_ context.Context, x int) (int, error) {
+		return x * 2, nil
+	}, pipeline.WithParallelism(3))
+	pipeline.Sink(doubled, "slow", func(ctx context.Context, _ int) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, p.Run(ctx), context.DeadlineExceeded)
+}
+
+func TestStop(t *testing.T) {
+	p := pipeline.New()
+	squares := pipeline.Then(infinite(p), "square", func(_ context.Context, x int) (int, error) {
+		return x * x, nil
+	}, pipeline.WithParallelism(4), pipeline.WithOrdered())
+	var c collector[int]
+	var count atomic.Int32
+	pipeline.Sink(squares, "collect", func(ctx context.Context, x int) error {
+		if count.Add(1) == 50 {
+			p.Stop()
+		}
+		return c.add(ctx, x)
+	})
+
+	assert.NoError(t, p.Run(context.Background()))
+	// everything produced went through, in order
+	stats := p.Stats()
+	got := c.get()
+	assert.Equal(t, stats[0].Out, uint64(len(got)))
+	assert.GreaterOrEqual(t, len(got), 50)
+	for i, x := range got {
+		assert.Equal(t, i*i, x)
+	}
+}
+
+func TestStopFromChan(t *testing.T) {
+	p := pipeline.New()
+	ch := make(chan int)
+	var c collector[int]
+	pipeline.Sink(pipeline.FromChan(p, "chan", ch), "collect", c.add)
+	go func() {
+		ch <- 1
+		ch <- 2
+		// nobody closes the channel, stopping unblocks the source
+		p.Stop()
+	}()
+	assert.NoError(t, p.Run(context.Background()))
+	assert.Equal(t, []int{1, 2}, c.get())
+}
+
+func TestSourceError(t *testing.T) {
+	p := pipeline.New()
+	src := pipeline.Generate(p, "broken", func(ctx context.Context, emit func(int) bool) error {
+		emit(1)
+		return errors.New("read failed")
+	})
+	pipeline.Sink(src, "discard", func(context.Context, int) error { return nil })
+	assert.EqualError(t, p.Run(context.Background()), "pipeline: stage broken: read failed")
+}
@@ -0,0 +1,405 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/queue"
+)
+
+// defaultBuffer is the default capacity of the buffer after a stage.
+const defaultBuffer = 16
+
+// StageOption configures a stage.
+type StageOption func(*stageConfig)
+
+type stageConfig struct {
+	parallelism int
+	ordered     bool
+	buffer      int
+	policy      ErrorPolicy
+}
+
+func newStageConfig(opts []StageOption) stageConfig {
+	cfg := stageConfig{parallelism: 1, buffer: defaultBuffer, policy: FailFast}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithParallelism sets the number of workers running the function of the stage, 1 by default.
+func WithParallelism(n int) StageOption {
+	return func(c *stageConfig) {
+		c.parallelism = max(n, 1)
+	}
+}
+
+// WithOrdered makes a parallel stage send its results in the order of its input.
+// A slow item holds back at most twice as many results as the parallelism of the stage.
+func WithOrdered() StageOption {
+	return func(c *stageConfig) {
+		c.ordered = true
+	}
+}
+
+// WithBuffer sets the capacity of the buffer between the stage and the next one, 16 by default.
+func WithBuffer(n int) StageOption {
+	return func(c *stageConfig) {
+		c.buffer = max(n, 1)
+	}
+}
+
+// WithErrorPolicy sets what the stage does with the items its function fails on, FailFast by default.
+func WithErrorPolicy(policy ErrorPolicy) StageOption {
+	return func(c *stageConfig) {
+		c.policy = policy
+	}
+}
+
+// PanicError is the error of an item the function of a stage panicked on.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("pipeline: stage panicked: %v", e.Value)
+}
+
+// envelope carries an item between two stages, or the end of the stream.
+type envelope[T any] struct {
+	seq   uint64
+	value T
+	eos   bool
+}
+
+// Stage is the output of a stage, to connect it to the next one with Then or Sink.
+// The output of a stage can be consumed by one stage only.
+type Stage[T any] struct {
+	p         *Pipeline
+	name      string
+	out       *queue.ArrayBlockingQueue[envelope[T]]
+	consumers int
+	consumer  string
+	sink      bool
+	stats     counters
+}
+
+func newStage[T any](p *Pipeline, name string, buffer int) *Stage[T] {
+	return &Stage[T]{p: p, name: name, out: queue.NewArrayBlockingQueue[envelope[T]](buffer)}
+}
+
+// Name returns the name of the stage.
+func (s *Stage[T]) Name() string {
+	return s.name
+}
+
+// connect records the stage consuming the output of s with the given number of workers.
+func (s *Stage[T]) connect(name string, workers int) {
+	if s.consumer != "" {
+		s.p.buildError(fmt.Errorf("pipeline: the output of stage %s is consumed by both %s and %s", s.name, s.consumer, name))
+		return
+	}
+	s.consumer, s.consumers = name, workers
+}
+
+func (s *Stage[T]) validate() error {
+	if !s.sink && s.consumer == "" {
+		return fmt.Errorf("pipeline: the output of stage %s is not consumed", s.name)
+	}
+	return nil
+}
+
+func (s *Stage[T]) snapshot() StageStats {
+	return s.stats.snapshot(s.name)
+}
+
+// emit sends the value downstream, and returns false if the context ends first.
+func (s *Stage[T]) emit(ctx context.Context, seq uint64, v T) bool {
+	if !s.sink {
+		if s.out.Push(ctx, envelope[T]{seq: seq, value: v}) != nil {
+			return false
+		}
+	}
+	s.stats.out.Add(1)
+	return true
+}
+
+// finish sends the end of the stream to each worker of the next stage.
+func (s *Stage[T]) finish(ctx context.Context) {
+	defer s.stats.end()
+	if s.sink {
+		return
+	}
+	for i := 0; i < s.consumers; i++ {
+		if s.out.Push(ctx, envelope[T]{eos: true}) != nil {
+			return
+		}
+	}
+}
+
+// source is a runner producing the items of a stage.
+type source[T any] struct {
+	*Stage[T]
+	produce func(ctx context.Context, emit func(T) bool) error
+}
+
+func (s *source[T]) start(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.stats.begin()
+		defer s.finish(ctx)
+
+		// the source alone is cancelled by Stop
+		srcCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			select {
+			case <-s.p.stopped():
+				cancel()
+			case <-srcCtx.Done():
+			}
+		}()
+
+		var seq uint64
+		// an item handed to emit is delivered even if the pipeline is stopping
+		emit := func(v T) bool {
+			if !s.emit(ctx, seq, v) {
+				return false
+			}
+			s.stats.in.Add(1)
+			seq++
+			return srcCtx.Err() == nil
+		}
+		e := s.produce(srcCtx, emit)
+		if e != nil && ctx.Err() == nil && !(srcCtx.Err() != nil && errors.Is(e, context.Canceled)) {
+			s.p.fail(&StageError{Stage: s.name, Err: e})
+		}
+	}()
+}
+
+// Generate adds a source stage to the pipeline. produce sends the items with emit,
+// until emit returns false because the pipeline is stopping, and returns.
+// The item passed to emit is delivered unless the run is cancelled.
+// Its context is cancelled when the pipeline stops, so it can wait for its input too.
+// The error it returns stops the pipeline, except context.Canceled after Stop.
+// The only option of a source is WithBuffer.
+//
+//	pipeline.Generate(p, "ticks", func(ctx context.Context, emit func(int) bool) error {
+//		for i := 0; emit(i); i++ {
+//		}
+//		return nil
+//	})
+func Generate[T any](p *Pipeline, name string, produce func(ctx context.Context, emit func(T) bool) error, opts ...StageOption) *Stage[T] {
+	cfg := newStageConfig(opts)
+	s := &source[T]{Stage: newStage[T](p, name, cfg.buffer), produce: produce}
+	p.add(s)
+	return s.Stage
+}
+
+// From adds a source stage sending the items of the slice.
+func From[T any](p *Pipeline, name string, items []T, opts ...StageOption) *Stage[T] {
+	return Generate(p, name, func(_ context.Context, emit func(T) bool) error {
+		for _, v := range items {
+			if !emit(v) {
+				return nil
+			}
+		}
+		return nil
+	}, opts...)
+}
+
+// FromChan adds a source stage sending the items received from the channel, until it is closed.
+func FromChan[T any](p *Pipeline, name string, ch <-chan T, opts ...StageOption) *Stage[T] {
+	return Generate(p, name, func(ctx context.Context, emit func(T) bool) error {
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case v, ok := <-ch:
+				if !ok || !emit(v) {
+					return nil
+				}
+			}
+		}
+	}, opts...)
+}
+
+// result is the outcome of the function of a stage on an item.
+type result[In, Out any] struct {
+	seq uint64
+	in  In
+	out Out
+	err error
+}
+
+// transform is a runner applying a function to the items of the previous stage.
+type transform[In, Out any] struct {
+	*Stage[Out]
+	prev *Stage[In]
+	fn   func(context.Context, In) (Out, error)
+	cfg  stageConfig
+}
+
+// call calls the function of the stage, recovering a panic into a PanicError.
+func (t *transform[In, Out]) call(ctx context.Context, v In) (out Out, e error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return t.fn(ctx, v)
+}
+
+func (t *transform[In, Out]) start(ctx context.Context, wg *sync.WaitGroup) {
+	t.stats.begin()
+	results := make(chan result[In, Out], t.cfg.parallelism)
+	// in order, the window bounds the results waiting for a slow one
+	var window chan struct{}
+	if t.cfg.ordered {
+		window = make(chan struct{}, 2*t.cfg.parallelism)
+	}
+
+	var workers sync.WaitGroup
+	workers.Add(t.cfg.parallelism)
+	for i := 0; i < t.cfg.parallelism; i++ {
+		go func() {
+			defer workers.Done()
+			t.work(ctx, results, window)
+		}()
+	}
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if t.forward(ctx, results, window) {
+			t.finish(ctx)
+		} else {
+			t.stats.end()
+		}
+	}()
+}
+
+// work runs the function on the items of the previous stage, until the end of its stream.
+func (t *transform[In, Out]) work(ctx context.Context, results chan<- result[In, Out], window chan struct{}) {
+	for {
+		if window != nil {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+		}
+		env, e := t.prev.out.TryPop(ctx)
+		if e != nil || env.eos {
+			return
+		}
+		t.stats.in.Add(1)
+		start := time.Now()
+		out, e := t.call(ctx, env.value)
+		t.stats.observe(time.Since(start))
+		select {
+		case results <- result[In, Out]{seq: env.seq, in: env.value, out: out, err: e}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// forward sends the results downstream, in order if the stage is ordered,
+// and applies the error policy to the failures.
+// It returns false if the pipeline is stopping.
+func (t *transform[In, Out]) forward(ctx context.Context, results <-chan result[In, Out], window chan struct{}) bool {
+	var seq uint64
+	handle := func(r result[In, Out]) bool {
+		if window != nil {
+			<-window
+		}
+		if r.err == nil {
+			ok := t.emit(ctx, seq, r.out)
+			seq++
+			return ok
+		}
+		t.stats.errors.Add(1)
+		switch t.cfg.policy {
+		case Skip:
+		case SendToDeadLetter:
+			t.p.deadLetter(DeadLetter{Stage: t.name, Item: r.in, Err: r.err})
+		default:
+			t.p.fail(&StageError{Stage: t.name, Err: r.err})
+			return false
+		}
+		return true
+	}
+
+	var next uint64
+	pending := make(map[uint64]result[In, Out])
+	for r := range results {
+		if window == nil {
+			if !handle(r) {
+				return false
+			}
+			continue
+		}
+		pending[r.seq] = r
+		for r, ok := pending[next]; ok; r, ok = pending[next] {
+			delete(pending, next)
+			next++
+			if !handle(r) {
+				return false
+			}
+		}
+	}
+	return ctx.Err() == nil
+}
+
+// Then adds a stage running fn on the items of the previous stage, and returns its output.
+//
+//	parsed := pipeline.Then(lines, "parse", func(ctx context.Context, line string) (Record, error) {
+//		return parse(line)
+//	}, pipeline.WithParallelism(4))
+func Then[In, Out any](prev *Stage[In], name string, fn func(ctx context.Context, in In) (Out, error), opts ...StageOption) *Stage[Out] {
+	cfg := newStageConfig(opts)
+	t := &transform[In, Out]{Stage: newStage[Out](prev.p, name, cfg.buffer), prev: prev, fn: fn, cfg: cfg}
+	prev.connect(name, cfg.parallelism)
+	prev.p.add(t)
+	return t.Stage
+}
+
+// Sink adds a final stage running fn on the items of the previous stage.
+func Sink[T any](prev *Stage[T], name string, fn func(ctx context.Context, in T) error, opts ...StageOption) {
+	s := Then(prev, name, func(ctx context.Context, in T) (struct{}, error) {
+		return struct{}{}, fn(ctx, in)
+	}, opts...)
+	s.sink = true
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package pipeline_test
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/pipeline"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// jitter sleeps longer on some items, to shuffle the parallel results.
+func jitter(_ context.Context, x int) (int, error) {
+	time.Sleep(time.Duration((x*7)%5) * 100 * time.Microsecond)
+	return x, nil
+}
+
+func TestOrdered(t *testing.T) {
+	p := pipeline.New()
+	numbers := pipeline.From(p, "numbers", seq(200))
+	shuffled := pipeline.Then(numbers, "jitter", jitter, pipeline.WithParallelism(8), pipeline.WithOrdered())
+	var c collector[int]
+	pipeline.Sink(shuffled, "collect", c.add)
+	assert.NoError(t, p.Run(context.Background()))
+	assert.Equal(t, seq(200), c.get())
+}
+
+func TestUnordered(t *testing.T) {
+	p := pipeline.New()
+	numbers := pipeline.From(p, "numbers", seq(200))
+	shuffled := pipeline.Then(numbers, "jitter", jitter, pipeline.WithParallelism(8))
+	var c collector[int]
+	pipeline.Sink(shuffled, "collect", c.add, pipeline.WithParallelism(3))
+	assert.NoError(t, p.Run(context.Background()))
+	got := c.get()
+	sort.Ints(got)
+	assert.Equal(t, seq(200), got)
+}
+
+func TestParallelism(t *testing.T) {
+	var running, peak atomic.Int32
+	p := pipeline.New()
+	numbers := pipeline.From(p, "numbers", seq(40))
+	pipeline.Sink(numbers, "work", func(context.Context, int) error {
+		n := running.Add(1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		running.Add(-1)
+		return nil
+	}, pipeline.WithParallelism(4))
+	assert.NoError(t, p.Run(context.Background()))
+	assert.LessOrEqual(t, peak.Load(), int32(4))
+	assert.Greater(t, peak.Load(), int32(1))
+}
+
+func TestBackpressure(t *testing.T) {
+	p := pipeline.New()
+	src := pipeline.Generate(p, "naturals", func(ctx context.Context, emit func(int) bool) error {
+		for i := 0; emit(i); i++ {
+		}
+		return nil
+	}, pipeline.WithBuffer(2))
+	passed := pipeline.Then(src, "pass", func(_ context.Context, x int) (int, error) {
+		return x, nil
+	}, pipeline.WithBuffer(2))
+	var consumed atomic.Int64
+	pipeline.Sink(passed, "slow", func(context.Context, int) error {
+		time.Sleep(time.Millisecond)
+		if consumed.Add(1) == 20 {
+			p.Stop()
+		}
+		return nil
+	})
+	assert.NoError(t, p.Run(context.Background()))
+
+	// the source was held back by the bounded buffers
+	produced := p.Stats()[0].Out
+	assert.LessOrEqual(t, produced, uint64(consumed.Load())+2+2+2)
+	assert.Equal(t, uint64(consumed.Load()), produced)
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package pipeline
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// StageStats are the statistics of a stage.
+type StageStats struct {
+	Name string
+	// In is the number of items the stage received, Out the number it sent downstream.
+	In, Out uint64
+	// Errors is the number of items the function of the stage failed on.
+	Errors uint64
+	// TotalLatency is the time spent in the function of the stage, summed over the items,
+	// and MaxLatency the longest of them.
+	TotalLatency, MaxLatency time.Duration
+	// Elapsed is the time from the start of the stage to its end, or to now if it is running.
+	Elapsed time.Duration
+}
+
+// MeanLatency returns the mean time spent in the function of the stage per item.
+func (s StageStats) MeanLatency() time.Duration {
+	if s.In == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.In)
+}
+
+// Throughput returns the number of items sent downstream per second.
+func (s StageStats) Throughput() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Out) / s.Elapsed.Seconds()
+}
+
+// counters are the live statistics of a stage.
+type counters struct {
+	in, out, errors   atomic.Uint64
+	totalLatency      atomic.Int64
+	maxLatency        atomic.Int64
+	mu                sync.Mutex
+	started, finished time.Time
+}
+
+// begin records the start of the stage.
+func (c *counters) begin() {
+	c.mu.Lock()
+	c.started = time.Now()
+	c.mu.Unlock()
+}
+
+// end records the end of the stage.
+func (c *counters) end() {
+	c.mu.Lock()
+	c.finished = time.Now()
+	c.mu.Unlock()
+}
+
+// observe records the latency of an item.
+func (c *counters) observe(d time.Duration) {
+	c.totalLatency.Add(int64(d))
+	for {
+		max := c.maxLatency.Load()
+		if int64(d) <= max || c.maxLatency.CompareAndSwap(max, int64(d)) {
+			return
+		}
+	}
+}
+
+// snapshot returns the statistics of the stage.
+func (c *counters) snapshot(name string) StageStats {
+	s := StageStats{
+		Name:         name,
+		In:           c.in.Load(),
+		Out:          c.out.Load(),
+		Errors:       c.errors.Load(),
+		TotalLatency: time.Duration(c.totalLatency.Load()),
+		MaxLatency:   time.Duration(c.maxLatency.Load()),
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case c.started.IsZero():
+	case c.finished.IsZero():
+		s.Elapsed = time.Since(c.started)
+	default:
+		s.Elapsed = c.finished.Sub(c.started)
+	}
+	return s
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package pipeline_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/pipeline"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	p := pipeline.New()
+	numbers := pipeline.From(p, "numbers", seq(20))
+	slow := pipeline.Then(numbers, "slow", func(_ context.Context, x int) (int, error) {
+		time.Sleep(time.Millisecond)
+		return x, nil
+	}, pipeline.WithParallelism(2))
+	pipeline.Sink(slow, "discard", func(context.Context, int) error { return nil })
+
+	before := p.Stats()
+	assert.Equal(t, "numbers", before[0].Name)
+	assert.Zero(t, before[1].Elapsed)
+	assert.Zero(t, before[1].Throughput())
+	assert.Zero(t, before[1].MeanLatency())
+
+	assert.NoError(t, p.Run(context.Background()))
+	stats := p.Stats()
+	assert.Len(t, stats, 3)
+	s := stats[1]
+	assert.Equal(t, "slow", s.Name)
+	assert.Equal(t, uint64(20), s.In)
+	assert.Equal(t, uint64(20), s.Out)
+	assert.Zero(t, s.Errors)
+	assert.GreaterOrEqual(t, s.MeanLatency(), time.Millisecond)
+	assert.GreaterOrEqual(t, s.MaxLatency, s.MeanLatency())
+	assert.GreaterOrEqual(t, s.TotalLatency, 20*time.Millisecond)
+	assert.Greater(t, s.Elapsed, time.Duration(0))
+	assert.Greater(t, s.Throughput(), 0.0)
+	// two workers: the stage took about half its total latency
+	assert.Less(t, s.Elapsed, s.TotalLatency)
+
+	assert.Equal(t, uint64(20), stats[0].Out)
+	assert.Equal(t, uint64(20), stats[2].In)
+}
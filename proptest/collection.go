@@ -0,0 +1,242 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package proptest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+)
+
+// sliceTree returns the tree of the slice of the values of the element trees,
+// shrinking first by removing chunks of elements, then by shrinking each element.
+func sliceTree[T any](ts []tree[T], minLen int) tree[[]T] {
+	value := make([]T, len(ts))
+	for i, t := range ts {
+		value[i] = t.value
+	}
+	return tree[[]T]{value: value, shrink: func() []tree[[]T] {
+		var result []tree[[]T]
+		for k := len(ts) - minLen; k > 0; k /= 2 {
+			for i := 0; i+k <= len(ts); i += k {
+				rest := make([]tree[T], 0, len(ts)-k)
+				rest = append(append(rest, ts[:i]...), ts[i+k:]...)
+				result = append(result, sliceTree(rest, minLen))
+			}
+		}
+		for i, t := range ts {
+			for _, c := range t.children() {
+				shrunk := make([]tree[T], len(ts))
+				copy(shrunk, ts)
+				shrunk[i] = c
+				result = append(result, sliceTree(shrunk, minLen))
+			}
+		}
+		return result
+	}}
+}
+
+// SliceOf returns a generator of slices of values of g, of length up to the size.
+// The slices shrink towards shorter slices of smaller values.
+func SliceOf[T any](g Gen[T]) Gen[[]T] {
+	return Gen[[]T]{run: func(r *rand.Rand, size int) tree[[]T] {
+		return SliceOfN(g, 0, size).run(r, size)
+	}}
+}
+
+// SliceOfN returns a generator of slices of values of g, of length in [min, max].
+func SliceOfN[T any](g Gen[T], min, max int) Gen[[]T] {
+	if min < 0 || min > max {
+		panic(fmt.Sprintf("proptest: SliceOfN with invalid length range [%d, %d]", min, max))
+	}
+	return Gen[[]T]{run: func(r *rand.Rand, size int) tree[[]T] {
+		ts := make([]tree[T], min+r.IntN(max-min+1))
+		for i := range ts {
+			ts[i] = g.run(r, size)
+		}
+		return sliceTree(ts, min)
+	}}
+}
+
+// Rune returns a generator of printable ASCII runes, mostly, with some non-ASCII ones.
+// The runes shrink towards 'a'.
+func Rune() Gen[rune] {
+	ascii := Map(Range(0, 94), func(i int) rune {
+		// shrink towards 'a' rather than ' '
+		return ' ' + rune((i+'a'-' ')%95)
+	})
+	unicode := Elements('é', 'ß', 'λ', 'Я', '中', '日', '€', '😀')
+	return Gen[rune]{run: func(r *rand.Rand, size int) tree[rune] {
+		if r.IntN(10) == 0 {
+			return unicode.run(r, size)
+		}
+		return ascii.run(r, size)
+	}}
+}
+
+// String returns a generator of strings of Rune, shrinking towards shorter strings of 'a'.
+func String() Gen[string] {
+	return StringOf(Rune())
+}
+
+// StringOf returns a generator of strings of the runes of g.
+func StringOf(g Gen[rune]) Gen[string] {
+	return Map(SliceOf(g), func(s []rune) string {
+		return string(s)
+	})
+}
+
+// MapOf returns a generator of maps with keys of k and values of v, of up to size entries.
+// The maps shrink towards smaller maps of smaller entries.
+func MapOf[K comparable, V any](k Gen[K], v Gen[V]) Gen[map[K]V] {
+	return Map(SliceOf(Zip(k, v)), func(entries []functional.Pair[K, V]) map[K]V {
+		m := make(map[K]V, len(entries))
+		for _, e := range entries {
+			m[e.First()] = e.Second()
+		}
+		return m
+	})
+}
+
+// OptionalOf returns a generator of Optionals of values of g, Nothing one time out of four.
+// The Optionals shrink towards Nothing.
+func OptionalOf[T any](g Gen[T]) Gen[functional.Optional[T]] {
+	return Gen[functional.Optional[T]]{run: func(r *rand.Rand, size int) tree[functional.Optional[T]] {
+		if r.IntN(4) == 0 {
+			return leaf(functional.Nothing[T]())
+		}
+		t := mapTree(g.run(r, size), functional.Just[T])
+		return tree[functional.Optional[T]]{value: t.value, shrink: func() []tree[functional.Optional[T]] {
+			return append([]tree[functional.Optional[T]]{leaf(functional.Nothing[T]())}, t.children()...)
+		}}
+	}}
+}
+
+// zipTree returns the tree of the pairs of values of the trees, shrinking the first value first.
+func zipTree[A, B any](a tree[A], b tree[B]) tree[functional.Pair[A, B]] {
+	return tree[functional.Pair[A, B]]{value: functional.PairOf(a.value, b.value), shrink: func() []tree[functional.Pair[A, B]] {
+		var result []tree[functional.Pair[A, B]]
+		for _, c := range a.children() {
+			result = append(result, zipTree(c, b))
+		}
+		for _, c := range b.children() {
+			result = append(result, zipTree(a, c))
+		}
+		return result
+	}}
+}
+
+// Zip returns a generator of pairs of values of the generators, shrinking each value.
+func Zip[A, B any](a Gen[A], b Gen[B]) Gen[functional.Pair[A, B]] {
+	return Gen[functional.Pair[A, B]]{run: func(r *rand.Rand, size int) tree[functional.Pair[A, B]] {
+		return zipTree(a.run(r, size), b.run(r, size))
+	}}
+}
+
+// Zip3 returns a generator of triples of values of the generators, shrinking each value.
+func Zip3[A, B, C any](a Gen[A], b Gen[B], c Gen[C]) Gen[functional.Triple[A, B, C]] {
+	return Map(Zip(a, Zip(b, c)), func(p functional.Pair[A, functional.Pair[B, C]]) functional.Triple[A, B, C] {
+		return functional.TripleOf(p.First(), p.Second().First(), p.Second().Second())
+	})
+}
+
+// Field is a generator of a struct field, see Struct.
+type Field struct {
+	name string
+	run  func(r *rand.Rand, size int) tree[any]
+	typ  reflect.Type
+}
+
+// FieldOf returns the generator of the struct field with the given name.
+func FieldOf[T any](name string, g Gen[T]) Field {
+	return Field{
+		name: name,
+		run: func(r *rand.Rand, size int) tree[any] {
+			return mapTree(g.run(r, size), func(v T) any { return v })
+		},
+		typ: reflect.TypeOf((*T)(nil)).Elem(),
+	}
+}
+
+// Struct returns a generator of structs S whose fields are generated by the field generators,
+// the other fields being left zero. The structs shrink by shrinking each field.
+// It panics if a field does not exist, is unexported, or has another type than its generator.
+//
+//	proptest.Struct[Person](
+//		proptest.FieldOf("Name", proptest.String()),
+//		proptest.FieldOf("Age", proptest.Range(0, 150)),
+//	)
+func Struct[S any](fields ...Field) Gen[S] {
+	typ := reflect.TypeOf((*S)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("proptest: Struct of non-struct type %v", typ))
+	}
+	index := make([][]int, len(fields))
+	for i, f := range fields {
+		sf, ok := typ.FieldByName(f.name)
+		switch {
+		case !ok:
+			panic(fmt.Sprintf("proptest: %v has no field %s", typ, f.name))
+		case !sf.IsExported():
+			panic(fmt.Sprintf("proptest: field %s of %v is unexported", f.name, typ))
+		case sf.Type != f.typ:
+			panic(fmt.Sprintf("proptest: field %s of %v is %v, not %v", f.name, typ, sf.Type, f.typ))
+		}
+		index[i] = sf.Index
+	}
+	build := func(values []any) S {
+		var s S
+		v := reflect.ValueOf(&s).Elem()
+		for i, value := range values {
+			if value != nil {
+				v.FieldByIndex(index[i]).Set(reflect.ValueOf(value))
+			}
+		}
+		return s
+	}
+	return Gen[S]{run: func(r *rand.Rand, size int) tree[S] {
+		ts := make([]tree[any], len(fields))
+		for i, f := range fields {
+			ts[i] = f.run(r, size)
+		}
+		return mapTree(fieldsTree(ts), build)
+	}}
+}
+
+// fieldsTree returns the tree of the values of the trees, shrinking each one in turn.
+func fieldsTree(ts []tree[any]) tree[[]any] {
+	value := make([]any, len(ts))
+	for i, t := range ts {
+		value[i] = t.value
+	}
+	return tree[[]any]{value: value, shrink: func() []tree[[]any] {
+		var result []tree[[]any]
+		for i, t := range ts {
+			for _, c := range t.children() {
+				shrunk := make([]tree[any], len(ts))
+				copy(shrunk, ts)
+				shrunk[i] = c
+				result = append(result, fieldsTree(shrunk))
+			}
+		}
+		return result
+	}}
+}
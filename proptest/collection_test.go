@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package proptest_test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/proptest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliceOf(t *testing.T) {
+	samples := proptest.SliceOf(proptest.Int()).Sample(1, 100)
+	assert.Empty(t, samples[0])
+	for _, s := range samples {
+		assert.LessOrEqual(t, len(s), proptest.MaxSize)
+	}
+	for _, s := range proptest.SliceOfN(proptest.Int(), 2, 4).Sample(1, 100) {
+		assert.True(t, len(s) >= 2 && len(s) <= 4)
+	}
+	assert.Panics(t, func() { proptest.SliceOfN(proptest.Int(), 3, 2) })
+
+	// shrinks to the minimal slice: one element, itself minimal
+	f := proptest.Check(proptest.Config{}, proptest.SliceOf(proptest.Int()), func(s []int) bool {
+		return functional.Sum(s...) < 100
+	})
+	if assert.NotNil(t, f) {
+		assert.Equal(t, []int{100}, f.Shrunk)
+	}
+
+	// keeps the minimum length
+	f = proptest.Check(proptest.Config{}, proptest.SliceOfN(proptest.Range(0, 100), 3, 10), func(s []int) bool {
+		return false
+	})
+	if assert.NotNil(t, f) {
+		assert.Equal(t, []int{0, 0, 0}, f.Shrunk)
+	}
+}
+
+func TestString(t *testing.T) {
+	for _, s := range proptest.String().Sample(1, 200) {
+		assert.True(t, utf8.ValidString(s))
+	}
+	f := proptest.Check(proptest.Config{}, proptest.String(), func(s string) bool {
+		return !strings.ContainsAny(s, "xyz")
+	})
+	if assert.NotNil(t, f) {
+		assert.Equal(t, 1, utf8.RuneCountInString(f.Shrunk))
+		assert.True(t, strings.ContainsAny(f.Shrunk, "xyz"))
+	}
+	f = proptest.Check(proptest.Config{}, proptest.String(), func(s string) bool { return len(s) < 3 })
+	if assert.NotNil(t, f) {
+		assert.Equal(t, "aaa", f.Shrunk)
+	}
+
+	digits := proptest.StringOf(proptest.Elements('0', '1'))
+	for _, s := range digits.Sample(1, 50) {
+		assert.Empty(t, strings.Trim(s, "01"))
+	}
+}
+
+func TestMapOf(t *testing.T) {
+	g := proptest.MapOf(proptest.Range(0, 10), proptest.String())
+	for _, m := range g.Sample(1, 100) {
+		assert.LessOrEqual(t, len(m), 11)
+	}
+	f := proptest.Check(proptest.Config{}, g, func(m map[int]string) bool { return len(m) < 2 })
+	if assert.NotNil(t, f) {
+		assert.Equal(t, map[int]string{0: "", 1: ""}, f.Shrunk)
+	}
+}
+
+func TestOptionalOf(t *testing.T) {
+	present, absent := 0, 0
+	for _, o := range proptest.OptionalOf(proptest.Int()).Sample(1, 200) {
+		if o.IsPresent() {
+			present++
+		} else {
+			absent++
+		}
+	}
+	assert.Greater(t, present, absent)
+	assert.Greater(t, absent, 0)
+
+	f := proptest.Check(proptest.Config{}, proptest.OptionalOf(proptest.Range(0, 100)), func(o functional.Optional[int]) bool {
+		return o.OrElse(0) < 10
+	})
+	if assert.NotNil(t, f) {
+		assert.Equal(t, 10, f.Shrunk.Unwrap())
+	}
+}
+
+func TestZip(t *testing.T) {
+	f := proptest.Check(proptest.Config{}, proptest.Zip(proptest.Int(), proptest.Int()), func(p functional.Pair[int, int]) bool {
+		return p.First() < 10 || p.Second() < 20
+	})
+	if assert.NotNil(t, f) {
+		assert.Equal(t, functional.PairOf(10, 20), f.Shrunk)
+	}
+	g := proptest.Zip3(proptest.Const(1), proptest.Const("a"), proptest.Const(true))
+	assert.Equal(t, functional.TripleOf(1, "a", true), g.Sample(1, 1)[0])
+}
+
+type person struct {
+	Name  string
+	Age   int
+	Email functional.Optional[string]
+	Err   error
+	note  string
+}
+
+func TestStruct(t *testing.T) {
+	g := proptest.Struct[person](
+		proptest.FieldOf("Name", proptest.String()),
+		proptest.FieldOf("Age", proptest.Range(0, 150)),
+		proptest.FieldOf("Email", proptest.OptionalOf(proptest.String())),
+		proptest.FieldOf("Err", proptest.Const[error](nil)),
+	)
+	for _, p := range g.Sample(1, 100) {
+		assert.True(t, p.Age >= 0 && p.Age <= 150)
+		assert.NotNil(t, p.Email)
+	}
+	f := proptest.Check(proptest.Config{}, g, func(p person) bool { return p.Age < 18 || p.Name == "" })
+	if assert.NotNil(t, f) {
+		assert.Equal(t, "a", f.Shrunk.Name)
+		assert.Equal(t, 18, f.Shrunk.Age)
+		assert.False(t, f.Shrunk.Email.IsPresent())
+	}
+
+	assert.Panics(t, func() { proptest.Struct[int]() })
+	assert.Panics(t, func() { proptest.Struct[person](proptest.FieldOf("Nope", proptest.Int())) })
+	assert.Panics(t, func() { proptest.Struct[person](proptest.FieldOf("note", proptest.String())) })
+	assert.Panics(t, func() { proptest.Struct[person](proptest.FieldOf("Age", proptest.String())) })
+}
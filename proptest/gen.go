@@ -0,0 +1,192 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package proptest is a property-based testing library, in the spirit of QuickCheck.
+//
+// A property is checked against many random inputs drawn from typed generators.
+// When it fails, the counterexample is shrunk to a minimal one before being reported.
+// Runs are deterministic: the random source is seeded from the test name,
+// or from the PROPTEST_SEED environment variable to replay a reported failure.
+//
+//	func TestReverse(t *testing.T) {
+//		proptest.ForAll(t, proptest.SliceOf(proptest.Int()), func(s []int) bool {
+//			return slices.Equal(s, reverse(reverse(s)))
+//		})
+//	}
+package proptest
+
+import "math/rand/v2"
+
+// tree is a generated value with its shrinks, the smaller values to try first
+// if the value is a counterexample. The shrinks are computed lazily.
+type tree[T any] struct {
+	value  T
+	shrink func() []tree[T]
+}
+
+// leaf returns a tree which cannot be shrunk.
+func leaf[T any](v T) tree[T] {
+	return tree[T]{value: v}
+}
+
+// children returns the shrinks of the tree.
+func (t tree[T]) children() []tree[T] {
+	if t.shrink == nil {
+		return nil
+	}
+	return t.shrink()
+}
+
+// mapTree applies fn to every value of the tree.
+func mapTree[T, U any](t tree[T], fn func(T) U) tree[U] {
+	return tree[U]{
+		value: fn(t.value),
+		shrink: func() []tree[U] {
+			children := t.children()
+			result := make([]tree[U], len(children))
+			for i, c := range children {
+				result[i] = mapTree(c, fn)
+			}
+			return result
+		},
+	}
+}
+
+// filterTree removes the shrinks not satisfying the predicate, the root must satisfy it.
+func filterTree[T any](t tree[T], pred func(T) bool) tree[T] {
+	return tree[T]{
+		value: t.value,
+		shrink: func() []tree[T] {
+			var result []tree[T]
+			for _, c := range t.children() {
+				if pred(c.value) {
+					result = append(result, filterTree(c, pred))
+				}
+			}
+			return result
+		},
+	}
+}
+
+// Gen is a generator of random values of type T, which knows how to shrink them.
+// The size is a hint in [0, 100] of how big the values should be:
+// the first runs of a check use small sizes, the last ones big sizes.
+type Gen[T any] struct {
+	run func(r *rand.Rand, size int) tree[T]
+}
+
+// NewGen creates a generator from a function generating a value, and a function
+// returning the smaller candidates of a value, the most aggressive first.
+// The shrink function may be nil, then the values are not shrunk.
+func NewGen[T any](generate func(r *rand.Rand, size int) T, shrink func(T) []T) Gen[T] {
+	var grow func(v T) tree[T]
+	grow = func(v T) tree[T] {
+		if shrink == nil {
+			return leaf(v)
+		}
+		return tree[T]{value: v, shrink: func() []tree[T] {
+			candidates := shrink(v)
+			result := make([]tree[T], len(candidates))
+			for i, c := range candidates {
+				result[i] = grow(c)
+			}
+			return result
+		}}
+	}
+	return Gen[T]{run: func(r *rand.Rand, size int) tree[T] {
+		return grow(generate(r, size))
+	}}
+}
+
+// Generate returns a random value of the given size.
+func (g Gen[T]) Generate(r *rand.Rand, size int) T {
+	return g.run(r, size).value
+}
+
+// Sample returns n values generated from the seed, with growing sizes, e.g. to inspect a generator.
+func (g Gen[T]) Sample(seed uint64, n int) []T {
+	r := newRand(seed)
+	result := make([]T, n)
+	for i := range result {
+		result[i] = g.Generate(r, sizeAt(i, n, MaxSize))
+	}
+	return result
+}
+
+// Filter returns a generator of the values satisfying the predicate.
+// It retries up to 100 times, then panics: prefer generating valid values directly.
+func (g Gen[T]) Filter(pred func(T) bool) Gen[T] {
+	return Gen[T]{run: func(r *rand.Rand, size int) tree[T] {
+		for i := 0; i < 100; i++ {
+			if t := g.run(r, size); pred(t.value) {
+				return filterTree(t, pred)
+			}
+			// bigger values may be more likely to satisfy it
+			size++
+		}
+		panic("proptest: Filter discarded too many values")
+	}}
+}
+
+// Map returns a generator of the results of fn on the values of g.
+// The results are shrunk through the values of g.
+func Map[T, U any](g Gen[T], fn func(T) U) Gen[U] {
+	return Gen[U]{run: func(r *rand.Rand, size int) tree[U] {
+		return mapTree(g.run(r, size), fn)
+	}}
+}
+
+// Bind returns a generator running the generator fn returns for a value of g,
+// e.g. to generate an index of a generated slice. The results are not shrunk through g.
+func Bind[T, U any](g Gen[T], fn func(T) Gen[U]) Gen[U] {
+	return Gen[U]{run: func(r *rand.Rand, size int) tree[U] {
+		return fn(g.run(r, size).value).run(r, size)
+	}}
+}
+
+// Const returns a generator of the value.
+func Const[T any](v T) Gen[T] {
+	return Gen[T]{run: func(*rand.Rand, int) tree[T] {
+		return leaf(v)
+	}}
+}
+
+// Elements returns a generator picking one of the values, shrinking towards the first ones.
+func Elements[T any](values ...T) Gen[T] {
+	if len(values) == 0 {
+		panic("proptest: Elements needs at least one value")
+	}
+	return Map(Range(0, len(values)-1), func(i int) T {
+		return values[i]
+	})
+}
+
+// OneOf returns a generator running one of the generators, shrinking towards the first ones.
+func OneOf[T any](gens ...Gen[T]) Gen[T] {
+	if len(gens) == 0 {
+		panic("proptest: OneOf needs at least one generator")
+	}
+	return Bind(Elements(gens...), func(g Gen[T]) Gen[T] {
+		return g
+	})
+}
+
+// Bool returns a generator of booleans, shrinking towards false.
+func Bool() Gen[bool] {
+	return Elements(false, true)
+}
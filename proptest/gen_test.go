@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package proptest_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/proptest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGen(t *testing.T) {
+	// even numbers shrinking by halving towards 0
+	g := proptest.NewGen(
+		func(r *rand.Rand, size int) int { return 2 * (size + 1) },
+		func(x int) []int {
+			if x == 0 {
+				return nil
+			}
+			return []int{x / 2}
+		},
+	)
+	assert.Equal(t, 2, g.Generate(rand.New(rand.NewPCG(1, 1)), 0))
+
+	f := proptest.Check(proptest.Config{}, g, func(x int) bool { return x < 50 })
+	if assert.NotNil(t, f) {
+		assert.GreaterOrEqual(t, f.Original, 50)
+		assert.GreaterOrEqual(t, f.Shrunk, 50)
+		assert.Less(t, f.Shrunk, 100)
+	}
+
+	noShrink := proptest.NewGen(func(*rand.Rand, int) int { return 7 }, nil)
+	f = proptest.Check(proptest.Config{}, noShrink, func(x int) bool { return false })
+	if assert.NotNil(t, f) {
+		assert.Equal(t, 0, f.Shrinks)
+	}
+}
+
+func TestSample(t *testing.T) {
+	g := proptest.Range(0, 1000)
+	assert.Equal(t, g.Sample(42, 20), g.Sample(42, 20))
+	assert.NotEqual(t, g.Sample(42, 20), g.Sample(43, 20))
+}
+
+func TestMapFilter(t *testing.T) {
+	even := proptest.Map(proptest.Range(0, 1000), func(x int) int { return x * 2 })
+	for _, x := range even.Sample(1, 100) {
+		assert.Equal(t, 0, x%2)
+	}
+	odd := proptest.Range(0, 1000).Filter(func(x int) bool { return x%2 == 1 })
+	for _, x := range odd.Sample(1, 100) {
+		assert.Equal(t, 1, x%2)
+	}
+
+	// mapped and filtered values still shrink, keeping the filter
+	f := proptest.Check(proptest.Config{}, odd, func(x int) bool { return x < 100 })
+	if assert.NotNil(t, f) {
+		assert.Equal(t, 1, f.Shrunk%2)
+		assert.True(t, f.Shrunk >= 100 && f.Shrunk <= f.Original)
+	}
+	f = proptest.Check(proptest.Config{}, even, func(x int) bool { return x < 100 })
+	if assert.NotNil(t, f) {
+		assert.Equal(t, 100, f.Shrunk)
+	}
+
+	never := proptest.Int().Filter(func(int) bool { return false })
+	assert.Panics(t, func() { never.Sample(1, 1) })
+}
+
+func TestChoices(t *testing.T) {
+	assert.Equal(t, []int{7, 7, 7}, proptest.Const(7).Sample(1, 3))
+
+	seen := map[string]bool{}
+	for _, s := range proptest.Elements("a", "b", "c").Sample(1, 100) {
+		seen[s] = true
+	}
+	assert.Equal(t, map[string]bool{"a": true, "b": true, "c": true}, seen)
+
+	for _, x := range proptest.OneOf(proptest.Range(0, 9), proptest.Range(100, 109)).Sample(1, 100) {
+		assert.True(t, x < 10 || (x >= 100 && x < 110))
+	}
+
+	bools := map[bool]bool{}
+	for _, b := range proptest.Bool().Sample(1, 100) {
+		bools[b] = true
+	}
+	assert.Len(t, bools, 2)
+
+	assert.Panics(t, func() { proptest.Elements[int]() })
+	assert.Panics(t, func() { proptest.OneOf[int]() })
+}
+
+func TestBind(t *testing.T) {
+	// a slice and a valid index of it
+	g := proptest.Bind(proptest.SliceOfN(proptest.Int(), 1, 10), func(s []int) proptest.Gen[int] {
+		return proptest.Map(proptest.Range(0, len(s)-1), func(i int) int { return s[i] })
+	})
+	assert.Len(t, g.Sample(1, 50), 50)
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package proptest
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+)
+
+// isFloat returns true if T is a floating-point type.
+func isFloat[T functional.Real]() bool {
+	half := 0.5
+	return T(half) != 0
+}
+
+// origin returns the value of [min, max] closest to 0, which values shrink towards.
+func origin[T functional.Real](min, max T) T {
+	switch {
+	case min > 0:
+		return min
+	case max < 0:
+		return max
+	default:
+		return 0
+	}
+}
+
+// scale returns the bound moved towards the origin according to the size,
+// so that small sizes generate small values.
+func scale[T functional.Real](bound, o T, size int) T {
+	if size >= MaxSize {
+		return bound
+	}
+	f := float64(o) + (float64(bound)-float64(o))*float64(size)/MaxSize
+	if bound < o {
+		if f <= float64(bound) {
+			return bound
+		}
+	} else if f >= float64(bound) {
+		return bound
+	}
+	return T(f)
+}
+
+// Range returns a generator of numbers in [min, max], for any integer or floating-point type.
+// Small sizes generate numbers close to the origin, the number of [min, max] closest to 0,
+// and the bounds and the origin are generated more often, as they often are edge cases.
+// The numbers shrink towards the origin.
+//
+//	proptest.Range(-10, 10)         // Gen[int]
+//	proptest.Range[uint8](0, 255)   // Gen[uint8]
+//	proptest.Range(0.0, 1.0)        // Gen[float64]
+func Range[T functional.Real](min, max T) Gen[T] {
+	if min > max {
+		panic("proptest: Range with min > max")
+	}
+	o := origin(min, max)
+	float := isFloat[T]()
+	return Gen[T]{run: func(r *rand.Rand, size int) tree[T] {
+		var v T
+		switch lo, hi := scale(min, o, size), scale(max, o, size); {
+		case r.IntN(10) == 0:
+			v = []T{min, max, o}[r.IntN(3)]
+		case float:
+			v = T(float64(lo) + r.Float64()*(float64(hi)-float64(lo)))
+			// rounding may get out of the bounds
+			if v < lo {
+				v = lo
+			} else if v > hi {
+				v = hi
+			}
+		default:
+			// two's complement makes the span right for signed types too
+			span := uint64(hi) - uint64(lo)
+			off := r.Uint64()
+			if span < math.MaxUint64 {
+				off = r.Uint64N(span + 1)
+			}
+			v = T(uint64(lo) + off)
+		}
+		return towards(o, v, float)
+	}}
+}
+
+// towards returns the tree of v shrinking towards the origin o, by halving the distance:
+// o first, then values closer and closer to v.
+func towards[T functional.Real](o, v T, float bool) tree[T] {
+	return tree[T]{value: v, shrink: func() []tree[T] {
+		if v == o || v != v {
+			return nil
+		}
+		var result []tree[T]
+		if float && T(math.Trunc(float64(v))) != v && T(math.Trunc(float64(v))) != o {
+			result = append(result, towards(o, T(math.Trunc(float64(v))), float))
+		}
+		// v and o are on the same side of 0 or o is 0, so v - o does not overflow
+		d := v - o
+		if float {
+			// search the exponent first, or a huge float would take a thousand halvings
+			for e := 512; e >= 1; e /= 2 {
+				if c := T(float64(o) + math.Ldexp(float64(d), -e)); c != o && c != v {
+					result = append(result, towards(o, c, float))
+				}
+			}
+		}
+		for i := 0; i < 64 && d != 0; i++ {
+			result = append(result, towards(o, v-d, float))
+			d /= 2
+			if float && math.Abs(float64(d)) < 1e-9 {
+				break
+			}
+		}
+		return result
+	}}
+}
+
+// Int returns a generator of ints, shrinking towards 0.
+func Int() Gen[int] {
+	return Range(math.MinInt, math.MaxInt)
+}
+
+// Float64 returns a generator of finite float64s, shrinking towards 0.
+func Float64() Gen[float64] {
+	return Range(-math.MaxFloat64/2, math.MaxFloat64/2)
+}
+
+// Complex returns a generator of complex numbers whose parts are in [min, max].
+func Complex[T ~complex64 | ~complex128](min, max float64) Gen[T] {
+	return Map(Zip(Range(min, max), Range(min, max)), func(p functional.Pair[float64, float64]) T {
+		return T(complex(p.First(), p.Second()))
+	})
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package proptest_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/proptest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func checkRange[T int8 | uint8 | int | int64 | uint64 | float32 | float64](t *testing.T, min, max T) {
+	for _, x := range proptest.Range(min, max).Sample(7, 1000) {
+		if !assert.True(t, x >= min && x <= max, "%v not in [%v, %v]", x, min, max) {
+			return
+		}
+	}
+}
+
+func TestRangeBounds(t *testing.T) {
+	checkRange[int8](t, math.MinInt8, math.MaxInt8)
+	checkRange[uint8](t, 0, math.MaxUint8)
+	checkRange(t, -5, 5)
+	checkRange(t, 10, 20)
+	checkRange(t, -20, -10)
+	checkRange(t, 3, 3)
+	checkRange[int64](t, math.MinInt64, math.MaxInt64)
+	checkRange[uint64](t, 0, math.MaxUint64)
+	checkRange[uint64](t, math.MaxUint64-1, math.MaxUint64)
+	checkRange(t, -1.5, 2.5)
+	checkRange[float32](t, 0, 1)
+	checkRange(t, -math.MaxFloat64/2, math.MaxFloat64/2)
+
+	assert.Panics(t, func() { proptest.Range(1, 0) })
+}
+
+func TestRangeSizes(t *testing.T) {
+	// the first values are small, the last ones spread over the range
+	s := proptest.Int().Sample(1, 100)
+	assert.Equal(t, 0, s[0])
+	big := 0
+	for _, x := range s[50:] {
+		if x > 1<<40 || x < -1<<40 {
+			big++
+		}
+	}
+	assert.Greater(t, big, 10)
+}
+
+func TestRangeShrinking(t *testing.T) {
+	cases := []struct {
+		min, max, threshold, want int
+	}{
+		{math.MinInt, math.MaxInt, 1000, 1000},
+		{math.MinInt, math.MaxInt, -1000, -1000},
+		{10, 1 << 20, 5000, 5000},
+		{-(1 << 20), -10, -5000, -5000},
+	}
+	for _, c := range cases {
+		f := proptest.Check(proptest.Config{Seed: 3}, proptest.Range(c.min, c.max), func(x int) bool {
+			if c.threshold >= 0 {
+				return x < c.threshold
+			}
+			return x > c.threshold
+		})
+		if assert.NotNil(t, f) {
+			assert.Equal(t, c.want, f.Shrunk)
+		}
+	}
+
+	f := proptest.Check(proptest.Config{}, proptest.Float64(), func(x float64) bool { return x < 1.5 })
+	if assert.NotNil(t, f) {
+		assert.InDelta(t, 1.5, f.Shrunk, 1e-6)
+	}
+	f = proptest.Check(proptest.Config{}, proptest.Range(0.0, 10.0), func(x float64) bool { return x < 3 })
+	if assert.NotNil(t, f) {
+		assert.Equal(t, 3.0, f.Shrunk)
+	}
+}
+
+func TestComplex(t *testing.T) {
+	for _, c := range proptest.Complex[complex128](-1, 1).Sample(1, 100) {
+		assert.True(t, math.Abs(real(c)) <= 1 && math.Abs(imag(c)) <= 1)
+	}
+	f := proptest.Check(proptest.Config{}, proptest.Complex[complex64](-10, 10), func(c complex64) bool {
+		return real(c) < 5
+	})
+	if assert.NotNil(t, f) {
+		assert.Equal(t, complex64(5), f.Shrunk)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package proptest
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+)
+
+const (
+	// MaxSize is the size of the values generated by the last runs of a check.
+	MaxSize = 100
+	// DefaultRuns is the number of values a property is checked against by default.
+	DefaultRuns = 100
+	// DefaultMaxShrinks is the maximum number of shrinking steps by default.
+	DefaultMaxShrinks = 1000
+	// SeedEnv is the environment variable overriding the seed of the checks run by ForAll.
+	SeedEnv = "PROPTEST_SEED"
+)
+
+// Config configures a check. The zero value runs DefaultRuns with the seed 0.
+type Config struct {
+	// Seed of the random source.
+	// For ForAllWith, 0 means the value of SeedEnv if set, or a hash of the test name.
+	Seed uint64
+	// Runs is the number of values to check, DefaultRuns if 0.
+	Runs int
+	// MaxShrinks is the maximum number of shrinking steps, DefaultMaxShrinks if 0.
+	MaxShrinks int
+}
+
+// Failure describes a counterexample of a property.
+type Failure[T any] struct {
+	// Seed reproducing the failure.
+	Seed uint64
+	// Run is the number of the run which failed, from 1.
+	Run int
+	// Original is the counterexample found.
+	Original T
+	// Shrunk is the minimal counterexample it was shrunk to.
+	Shrunk T
+	// Shrinks is the number of successful shrinking steps.
+	Shrinks int
+	// Panic is the value the property panicked with on Shrunk, nil if it returned false.
+	Panic any
+}
+
+// String describes the failure.
+func (f *Failure[T]) String() string {
+	s := fmt.Sprintf("property failed on run %d (seed %d, replay with %s=%d)\ncounterexample: %#v",
+		f.Run, f.Seed, SeedEnv, f.Seed, f.Shrunk)
+	if f.Panic != nil {
+		s += fmt.Sprintf("\npanic: %v", f.Panic)
+	}
+	if f.Shrinks > 0 {
+		s += fmt.Sprintf("\nshrunk %d times from: %#v", f.Shrinks, f.Original)
+	}
+	return s
+}
+
+// newRand returns a random source seeded with the seed.
+func newRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+}
+
+// sizeAt returns the size of the run i out of n, growing from 0 to max.
+func sizeAt(i, n, max int) int {
+	if n <= 1 {
+		return max
+	}
+	return i * max / (n - 1)
+}
+
+// holds evaluates the property, a panic making it fail.
+func holds[T any](prop func(T) bool, v T) (ok bool, panicked any) {
+	defer func() {
+		if p := recover(); p != nil {
+			ok, panicked = false, p
+		}
+	}()
+	return prop(v), nil
+}
+
+// Check checks the property against cfg.Runs values of g, and returns the shrunk
+// counterexample if it fails, nil otherwise. A property panicking fails.
+func Check[T any](cfg Config, g Gen[T], prop func(T) bool) *Failure[T] {
+	if cfg.Runs <= 0 {
+		cfg.Runs = DefaultRuns
+	}
+	if cfg.MaxShrinks <= 0 {
+		cfg.MaxShrinks = DefaultMaxShrinks
+	}
+	r := newRand(cfg.Seed)
+	for i := 0; i < cfg.Runs; i++ {
+		t := g.run(r, sizeAt(i, cfg.Runs, MaxSize))
+		ok, panicked := holds(prop, t.value)
+		if ok {
+			continue
+		}
+		f := &Failure[T]{Seed: cfg.Seed, Run: i + 1, Original: t.value, Panic: panicked}
+	shrinking:
+		for f.Shrinks < cfg.MaxShrinks {
+			for _, c := range t.children() {
+				if ok, panicked := holds(prop, c.value); !ok {
+					t, f.Panic = c, panicked
+					f.Shrinks++
+					continue shrinking
+				}
+			}
+			break
+		}
+		f.Shrunk = t.value
+		return f
+	}
+	return nil
+}
+
+// SeedOf returns the seed of the checks of the test run by ForAll:
+// the value of SeedEnv if set, or a hash of the test name.
+func SeedOf(t testing.TB) uint64 {
+	if s, ok := os.LookupEnv(SeedEnv); ok {
+		if seed, e := strconv.ParseUint(s, 10, 64); e == nil {
+			return seed
+		}
+		t.Logf("proptest: ignoring invalid %s=%q", SeedEnv, s)
+	}
+	h := fnv.New64a()
+	h.Write([]byte(t.Name()))
+	return h.Sum64()
+}
+
+// ForAll checks the property against DefaultRuns values of g, and fails the test
+// with the shrunk counterexample if it does not hold.
+//
+//	proptest.ForAll(t, proptest.SliceOf(proptest.Int()), func(s []int) bool {
+//		return functional.Sum(s...) >= 0 // fails, shrunk to []int{-1}
+//	})
+func ForAll[T any](t testing.TB, g Gen[T], prop func(T) bool) {
+	t.Helper()
+	ForAllWith(t, Config{}, g, prop)
+}
+
+// ForAllWith is like ForAll with a custom configuration.
+func ForAllWith[T any](t testing.TB, cfg Config, g Gen[T], prop func(T) bool) {
+	t.Helper()
+	if cfg.Seed == 0 {
+		cfg.Seed = SeedOf(t)
+	}
+	if f := Check(cfg, g, prop); f != nil {
+		t.Fatalf("proptest: %s", f)
+	}
+}
+
+// ForAll2 checks a property of two arguments, see ForAll.
+func ForAll2[A, B any](t testing.TB, a Gen[A], b Gen[B], prop func(A, B) bool) {
+	t.Helper()
+	ForAll(t, Zip(a, b), func(p functional.Pair[A, B]) bool {
+		return prop(p.Unpack())
+	})
+}
+
+// ForAll3 checks a property of three arguments, see ForAll.
+func ForAll3[A, B, C any](t testing.TB, a Gen[A], b Gen[B], c Gen[C], prop func(A, B, C) bool) {
+	t.Helper()
+	ForAll(t, Zip3(a, b, c), func(p functional.Triple[A, B, C]) bool {
+		return prop(p.Unpack())
+	})
+}
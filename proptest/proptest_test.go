@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package proptest_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/proptest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder is a testing.TB recording the failures instead of failing.
+type recorder struct {
+	testing.TB
+	failed string
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.failed = fmt.Sprintf(format, args...)
+}
+
+func (r *recorder) Logf(string, ...any) {}
+
+func TestForAll(t *testing.T) {
+	proptest.ForAll(t, proptest.SliceOf(proptest.Int()), func(s []int) bool {
+		r := slices.Clone(s)
+		slices.Reverse(r)
+		slices.Reverse(r)
+		return slices.Equal(s, r)
+	})
+	proptest.ForAll2(t, proptest.Int(), proptest.Int(), func(a, b int) bool {
+		return a+b == b+a
+	})
+	proptest.ForAll3(t, proptest.String(), proptest.String(), proptest.String(), func(a, b, c string) bool {
+		return (a+b)+c == a+(b+c)
+	})
+	proptest.ForAllWith(t, proptest.Config{Runs: 10}, proptest.Int(), func(int) bool { return true })
+}
+
+func TestForAllFailure(t *testing.T) {
+	r := &recorder{TB: t}
+	proptest.ForAll(r, proptest.SliceOf(proptest.Int()), func(s []int) bool {
+		return functional.Sum(s...) < 100
+	})
+	assert.Contains(t, r.failed, "counterexample: []int{100}")
+	assert.Contains(t, r.failed, proptest.SeedEnv+"=")
+
+	// the same test name gives the same seed
+	r2 := &recorder{TB: t}
+	proptest.ForAll(r2, proptest.SliceOf(proptest.Int()), func(s []int) bool {
+		return functional.Sum(s...) < 100
+	})
+	assert.Equal(t, r.failed, r2.failed)
+}
+
+func TestSeedEnv(t *testing.T) {
+	t.Setenv(proptest.SeedEnv, "12345")
+	r := &recorder{TB: t}
+	proptest.ForAll(r, proptest.Int(), func(x int) bool { return x < 10 })
+	assert.Contains(t, r.failed, "seed 12345")
+
+	t.Setenv(proptest.SeedEnv, "not a number")
+	r = &recorder{TB: t}
+	proptest.ForAll(r, proptest.Int(), func(x int) bool { return x < 10 })
+	assert.NotContains(t, r.failed, "seed 12345")
+}
+
+func TestCheck(t *testing.T) {
+	assert.Nil(t, proptest.Check(proptest.Config{}, proptest.Int(), func(int) bool { return true }))
+
+	// panics fail the property and are reported
+	f := proptest.Check(proptest.Config{Seed: 9}, proptest.SliceOf(proptest.Int()), func(s []int) bool {
+		return s[0] == s[0]
+	})
+	if assert.NotNil(t, f) {
+		assert.Equal(t, []int{}, f.Shrunk)
+		assert.NotNil(t, f.Panic)
+		assert.Equal(t, uint64(9), f.Seed)
+		assert.Contains(t, f.String(), "panic:")
+	}
+
+	// failures are reproducible from their seed
+	prop := func(s string) bool { return !strings.Contains(s, "ab") }
+	f1 := proptest.Check(proptest.Config{Seed: 5, Runs: 1000}, proptest.String(), prop)
+	f2 := proptest.Check(proptest.Config{Seed: 5, Runs: 1000}, proptest.String(), prop)
+	if assert.NotNil(t, f1) {
+		assert.Equal(t, f1, f2)
+		assert.Equal(t, "ab", f1.Shrunk)
+	}
+
+	// the number of shrinking steps is bounded
+	bounded := proptest.Check(proptest.Config{MaxShrinks: 1}, proptest.Range(1000, 1<<30), func(int) bool { return false })
+	if assert.NotNil(t, bounded) {
+		assert.LessOrEqual(t, bounded.Shrinks, 1)
+	}
+}
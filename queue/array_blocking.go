@@ -18,107 +18,163 @@
  */
 package queue
 
-import (
-	"context"
-	"sync"
-
-	"golang.org/x/sync/semaphore"
+import "context"
+
+// Engine is the synchronisation mechanism of an ArrayBlockingQueue.
+type Engine int
+
+const (
+	// SemaphoreEngine counts the elements and the free slots with golang.org/x/sync/semaphore,
+	// waking the waiting goroutines up in FIFO order. It is the default.
+	SemaphoreEngine Engine = iota
+	// CondEngine waits on sync.Cond conditions, woken up by the contexts when they end.
+	CondEngine
+	// ChannelEngine stores the elements in a buffered channel.
+	ChannelEngine
 )
 
+// String returns the name of the engine.
+func (e Engine) String() string {
+	switch e {
+	case SemaphoreEngine:
+		return "semaphore"
+	case CondEngine:
+		return "cond"
+	case ChannelEngine:
+		return "channel"
+	}
+	return "unknown"
+}
+
+// Option configures an ArrayBlockingQueue.
+type Option func(*options)
+
+type options struct {
+	engine Engine
+}
+
+// WithEngine selects the engine of the queue.
+//
+//	q := queue.NewArrayBlockingQueue[int](64, queue.WithEngine(queue.CondEngine))
+func WithEngine(e Engine) Option {
+	return func(o *options) {
+		o.engine = e
+	}
+}
+
+// arrayEngine is the implementation behind an ArrayBlockingQueue.
+type arrayEngine[T any] interface {
+	BlockingQueue[T]
+	Offer(t T) bool
+	PushAll(ctx context.Context, ts ...T) (int, error)
+	Poll() (T, bool)
+	Peek() (T, bool)
+	DrainTo(dst []T, max int) []T
+	Size() int
+	Cap() int
+}
+
 // ArrayBlockingQueue is a thread-safe bounded queue.
+// Its engine is chosen on creation, see Engine.
 type ArrayBlockingQueue[T any] struct {
-	items    []T
-	cap      int
-	head     int
-	tail     int
-	size     int
-	lock     sync.Mutex
-	notEmpty *semaphore.Weighted
-	notFull  *semaphore.Weighted
+	engine arrayEngine[T]
 }
 
+var _ BlockingQueue[int] = (*ArrayBlockingQueue[int])(nil)
+
 // NewArrayBlockingQueue creates a new ArrayBlockingQueue.
-func NewArrayBlockingQueue[T any](cap int) *ArrayBlockingQueue[T] {
-	notEmpty := semaphore.NewWeighted(int64(cap))
-	// Acquire all slots in the notEmpty semaphore.
-	// Not sure if it is necessary to panic if _ is actually an error.
-	_ = notEmpty.Acquire(context.Background(), int64(cap))
-	return &ArrayBlockingQueue[T]{
-		items:    make([]T, cap),
-		cap:      cap,
-		notEmpty: notEmpty,
-		notFull:  semaphore.NewWeighted(int64(cap)),
+func NewArrayBlockingQueue[T any](cap int, opts ...Option) *ArrayBlockingQueue[T] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
 	}
+	var e arrayEngine[T]
+	switch o.engine {
+	case CondEngine:
+		e = newCondEngine[T](cap)
+	case ChannelEngine:
+		e = newChannelEngine[T](cap, true)
+	default:
+		e = newSemaphoreEngine[T](cap)
+	}
+	return &ArrayBlockingQueue[T]{engine: e}
 }
 
 // Push adds an element to the queue.
 // When cancelled or timeout, return context.Canceled or context.DeadlineExceeded.
 // Shall always use errors.Is(err, context.Canceled) or errors.Is(err, context.DeadlineExceeded) to check the error.
+// Once the queue is closed, return ErrQueueClosed.
 func (q *ArrayBlockingQueue[T]) Push(ctx context.Context, t T) error {
-	// Acquire a slot in the semaphore, blocking if necessary.
-	if err := q.notFull.Acquire(ctx, 1); err != nil {
-		return err
-	}
-
-	q.lock.Lock()
-	defer q.lock.Unlock()
-
-	// Check if the context has already been cancelled when the lock is acquired.
-	if ctx.Err() != nil {
-		// Release a slot for the notFull semaphore.
-		q.notFull.Release(1)
-		return ctx.Err()
-	}
-
-	q.items[q.tail] = t
-	q.tail = (q.tail + 1) % q.cap
-	q.size++
-
-	// Release a slot for the notEmpty semaphore.
-	q.notEmpty.Release(1)
-
-	return nil
+	return q.engine.Push(ctx, t)
 }
 
 // TryPop removes and returns an element from the queue.
 // When cancelled or timeout, return context.Canceled or context.DeadlineExceeded.
 // Shall always use errors.Is(err, context.Canceled) or errors.Is(err, context.DeadlineExceeded) to check the error.
+// Once the queue is closed and drained, return ErrQueueClosed.
 func (q *ArrayBlockingQueue[T]) TryPop(ctx context.Context) (T, error) {
-	// Acquire a slot in the semaphore, blocking if necessary.
-	if err := q.notEmpty.Acquire(ctx, 1); err != nil {
-		var zero T
-		return zero, err
-	}
+	return q.engine.TryPop(ctx)
+}
 
-	q.lock.Lock()
-	defer q.lock.Unlock()
+// Offer adds an element to the queue if there is room, without blocking.
+// It returns false if the queue is full or closed.
+func (q *ArrayBlockingQueue[T]) Offer(t T) bool {
+	return q.engine.Offer(t)
+}
 
-	// Check if the context has already been cancelled when the lock is acquired.
-	if ctx.Err() != nil {
-		// Release a slot for the notEmpty semaphore.
-		q.notEmpty.Release(1)
-		var zero T
-		return zero, ctx.Err()
-	}
+// PushAll adds the elements to the queue in order, blocking while it is full,
+// and returns how many of them have been added.
+// The semaphore and cond engines take the lock once for as many elements as there is room for,
+// the channel engine sends the elements one by one.
+// When cancelled, timeout or closed, it returns the error of Push along with the count so far.
+func (q *ArrayBlockingQueue[T]) PushAll(ctx context.Context, ts ...T) (int, error) {
+	return q.engine.PushAll(ctx, ts...)
+}
 
-	t := q.items[q.head]
-	q.head = (q.head + 1) % q.cap
-	q.size--
+// Poll removes and returns the first element of the queue, without blocking.
+// It returns false if the queue is empty.
+func (q *ArrayBlockingQueue[T]) Poll() (T, bool) {
+	return q.engine.Poll()
+}
+
+// Peek returns the first element of the queue without removing it.
+// It returns false if the queue is empty.
+// With the ChannelEngine, it also returns false while a TryPop waits for the next element.
+func (q *ArrayBlockingQueue[T]) Peek() (T, bool) {
+	return q.engine.Peek()
+}
+
+// DrainTo removes at most max elements from the queue, all of them if max is negative,
+// appends them to dst and returns the extended slice. It never blocks,
+// and takes the lock once for the whole batch.
+//
+//	buf = q.DrainTo(buf[:0], 128)
+func (q *ArrayBlockingQueue[T]) DrainTo(dst []T, max int) []T {
+	return q.engine.DrainTo(dst, max)
+}
+
+// Close closes the queue: the pending and later pushes fail with ErrQueueClosed,
+// the pops drain the remaining elements, then fail with ErrQueueClosed.
+func (q *ArrayBlockingQueue[T]) Close() {
+	q.engine.Close()
+}
 
-	// Release a slot for the notFull semaphore.
-	q.notFull.Release(1)
+// CloseNow closes the queue and discards its elements.
+func (q *ArrayBlockingQueue[T]) CloseNow() {
+	q.engine.CloseNow()
+}
 
-	return t, nil
+// IsClosed reports whether the queue has been closed.
+func (q *ArrayBlockingQueue[T]) IsClosed() bool {
+	return q.engine.IsClosed()
 }
 
 // Size returns the number of elements in the queue, at the time of calling.
 func (q *ArrayBlockingQueue[T]) Size() int {
-	q.lock.Lock()
-	defer q.lock.Unlock()
-	return q.size
+	return q.engine.Size()
 }
 
 // Cap returns the capacity of the queue.
 func (q *ArrayBlockingQueue[T]) Cap() int {
-	return q.cap
+	return q.engine.Cap()
 }
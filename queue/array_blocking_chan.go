@@ -0,0 +1,307 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// channelEngine is the ArrayBlockingQueue engine storing the elements in a buffered channel.
+// The channel is closed once no push can send anymore, so that the pops drain it.
+// As a channel cannot be peeked, Peek moves the first element to a slot,
+// which the pops take before the channel.
+// A peeked element frees a place in the channel, so the capacity is kept by taken instead:
+// it holds a token per element, in the channel or the slot.
+//
+// To keep the order, the pops and Peek take the slot and receive under popLock,
+// and a TryPop only waits on the channel once the slot is empty.
+// Peek then leaves the first element to the waiting TryPops rather than taking it behind their back.
+type channelEngine[T any] struct {
+	items chan T
+	// taken is nil for a ChanQueue, which lends its channel out and never peeks
+	taken chan struct{}
+	// sending is read-locked by the pushes, closing the channel waits for them
+	sending   sync.RWMutex
+	closer    closer
+	closeOnce sync.Once
+
+	popLock sync.Mutex
+	// slot holds the element taken by Peek
+	slot    T
+	slotted bool
+	// waiting counts the TryPops waiting on the channel
+	waiting atomic.Int64
+}
+
+var _ arrayEngine[int] = (*channelEngine[int])(nil)
+
+// newChannelEngine creates a channelEngine holding at most cap elements.
+// Unless counted, the capacity is the one of the channel only, and Peek must not be used.
+func newChannelEngine[T any](cap int, counted bool) *channelEngine[T] {
+	q := &channelEngine[T]{items: make(chan T, cap), closer: newCloser()}
+	if counted {
+		q.taken = make(chan struct{}, cap)
+	}
+	return q
+}
+
+// send sends v on ch, blocking until there is room, ctx is done or the queue is closed.
+func send[E any](ctx context.Context, c *closer, ch chan<- E, v E) error {
+	select {
+	case ch <- v:
+		return nil
+	default:
+	}
+	select {
+	case ch <- v:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.ctx.Done():
+		return ErrQueueClosed
+	}
+}
+
+// push adds the element, sending is read-locked.
+func (q *channelEngine[T]) push(ctx context.Context, t T) error {
+	if q.closer.isClosed() {
+		return ErrQueueClosed
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if q.taken == nil {
+		return send(ctx, &q.closer, q.items, t)
+	}
+	if err := send(ctx, &q.closer, q.taken, struct{}{}); err != nil {
+		return err
+	}
+	// The token guarantees room in the channel.
+	q.items <- t
+	return nil
+}
+
+// free gives the token of a popped element back.
+func (q *channelEngine[T]) free() {
+	if q.taken != nil {
+		<-q.taken
+	}
+}
+
+// Push sends the element, unless the queue gets closed first.
+func (q *channelEngine[T]) Push(ctx context.Context, t T) error {
+	q.sending.RLock()
+	defer q.sending.RUnlock()
+	return q.push(ctx, t)
+}
+
+// TryPop takes the slot or receives an element, waiting for one if there is none,
+// unless the channel is closed and drained.
+func (q *channelEngine[T]) TryPop(ctx context.Context) (T, error) {
+	var zero T
+	q.popLock.Lock()
+	if err := ctx.Err(); err != nil {
+		drained := q.closer.isClosed() && !q.slotted && len(q.items) == 0
+		q.popLock.Unlock()
+		if drained {
+			return zero, ErrQueueClosed
+		}
+		return zero, err
+	}
+	if t, ok := q.unslot(); ok {
+		q.popLock.Unlock()
+		return t, nil
+	}
+	select {
+	case t, ok := <-q.items:
+		q.popLock.Unlock()
+		if !ok {
+			return zero, ErrQueueClosed
+		}
+		q.free()
+		return t, nil
+	default:
+	}
+	// the slot is empty, and Peek does not fill it while we wait
+	q.waiting.Add(1)
+	q.popLock.Unlock()
+	defer q.waiting.Add(-1)
+	select {
+	case t, ok := <-q.items:
+		if !ok {
+			return zero, ErrQueueClosed
+		}
+		q.free()
+		return t, nil
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// Offer sends the element if there is room right away.
+func (q *channelEngine[T]) Offer(t T) bool {
+	q.sending.RLock()
+	defer q.sending.RUnlock()
+	if q.closer.isClosed() {
+		return false
+	}
+	if q.taken == nil {
+		select {
+		case q.items <- t:
+			return true
+		default:
+			return false
+		}
+	}
+	select {
+	case q.taken <- struct{}{}:
+		q.items <- t
+		return true
+	default:
+		return false
+	}
+}
+
+// PushAll sends the elements one by one, read-locking sending once.
+func (q *channelEngine[T]) PushAll(ctx context.Context, ts ...T) (int, error) {
+	q.sending.RLock()
+	defer q.sending.RUnlock()
+	for i, t := range ts {
+		if err := q.push(ctx, t); err != nil {
+			return i, err
+		}
+	}
+	return len(ts), nil
+}
+
+// Poll takes the slot or receives an element if there is one right away.
+func (q *channelEngine[T]) Poll() (T, bool) {
+	q.popLock.Lock()
+	defer q.popLock.Unlock()
+	return q.take()
+}
+
+// Peek moves the first element to the slot, if it is not there already.
+// It reports no element while a TryPop is waiting, as the next element goes to that TryPop.
+func (q *channelEngine[T]) Peek() (T, bool) {
+	q.popLock.Lock()
+	defer q.popLock.Unlock()
+	var zero T
+	if q.slotted {
+		return q.slot, true
+	}
+	if q.waiting.Load() > 0 {
+		return zero, false
+	}
+	select {
+	case t, ok := <-q.items:
+		if !ok {
+			return zero, false
+		}
+		// t keeps its token while in the slot
+		q.slot, q.slotted = t, true
+		return t, true
+	default:
+		return zero, false
+	}
+}
+
+// unslot takes the element of the slot, if any. popLock must be held.
+func (q *channelEngine[T]) unslot() (T, bool) {
+	var zero T
+	if !q.slotted {
+		return zero, false
+	}
+	t := q.slot
+	q.slot, q.slotted = zero, false
+	q.free()
+	return t, true
+}
+
+// take takes the slot, or receives an element if there is one right away. popLock must be held.
+func (q *channelEngine[T]) take() (T, bool) {
+	if t, ok := q.unslot(); ok {
+		return t, true
+	}
+	select {
+	case t, ok := <-q.items:
+		if ok {
+			q.free()
+		}
+		return t, ok
+	default:
+		var zero T
+		return zero, false
+	}
+}
+
+// DrainTo takes the slot and receives the elements there are right away, under popLock once.
+func (q *channelEngine[T]) DrainTo(dst []T, max int) []T {
+	q.popLock.Lock()
+	defer q.popLock.Unlock()
+	for n := 0; max < 0 || n < max; n++ {
+		t, ok := q.take()
+		if !ok {
+			break
+		}
+		dst = append(dst, t)
+	}
+	return dst
+}
+
+// Close fails the waiting pushes, then closes the channel.
+func (q *channelEngine[T]) Close() {
+	q.closer.close()
+	q.sending.Lock()
+	defer q.sending.Unlock()
+	q.closeOnce.Do(func() {
+		close(q.items)
+	})
+}
+
+func (q *channelEngine[T]) CloseNow() {
+	q.Close()
+	q.popLock.Lock()
+	defer q.popLock.Unlock()
+	for {
+		if _, ok := q.take(); !ok {
+			return
+		}
+	}
+}
+
+func (q *channelEngine[T]) IsClosed() bool {
+	return q.closer.isClosed()
+}
+
+func (q *channelEngine[T]) Size() int {
+	q.popLock.Lock()
+	defer q.popLock.Unlock()
+	n := len(q.items)
+	if q.slotted {
+		n++
+	}
+	return n
+}
+
+func (q *channelEngine[T]) Cap() int {
+	return cap(q.items)
+}
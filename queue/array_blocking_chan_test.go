@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/queue"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelEnginePeek(t *testing.T) {
+	q := queue.NewArrayBlockingQueue[int](2, queue.WithEngine(queue.ChannelEngine))
+	assert.True(t, q.Offer(1))
+	assert.True(t, q.Offer(2))
+
+	v, ok := q.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	// the peeked element stays first
+	v, ok = q.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, 2, q.Size())
+	// the peeked element still counts against the capacity
+	assert.False(t, q.Offer(3))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, q.Push(ctx, 3), context.DeadlineExceeded)
+	n, err := q.PushAll(ctx, 3)
+	assert.Zero(t, n)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 2, q.Size())
+
+	assert.Equal(t, []int{1, 2}, q.DrainTo(nil, -1))
+	assert.Zero(t, q.Size())
+	assert.True(t, q.Offer(3))
+	assert.True(t, q.Offer(4))
+	assert.False(t, q.Offer(5))
+}
+
+func TestChannelEnginePeekLeavesWaitingTryPop(t *testing.T) {
+	q := queue.NewArrayBlockingQueue[int](2, queue.WithEngine(queue.ChannelEngine))
+	popped := make(chan int)
+	go func() {
+		v, err := q.TryPop(context.Background())
+		assert.NoError(t, err)
+		popped <- v
+	}()
+	time.Sleep(5 * time.Millisecond)
+	assert.True(t, q.Offer(7))
+	// whether Peek gets to the element first or not, the waiting TryPop pops it
+	q.Peek()
+	select {
+	case v := <-popped:
+		assert.Equal(t, 7, v)
+	case <-time.After(time.Second):
+		t.Fatal("TryPop missed the element")
+	}
+	assert.Zero(t, q.Size())
+}
+
+func TestChannelEnginePeekOrder(t *testing.T) {
+	const n = 20000
+	q := queue.NewArrayBlockingQueue[int](4, queue.WithEngine(queue.ChannelEngine))
+	ctx := context.Background()
+	go func() {
+		for i := range n {
+			assert.NoError(t, q.Push(ctx, i))
+		}
+		q.Close()
+	}()
+
+	stop := make(chan struct{})
+	peeked := make(chan struct{})
+	go func() {
+		defer close(peeked)
+		last := -1
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if v, ok := q.Peek(); ok {
+				// the first element only moves forward
+				assert.GreaterOrEqual(t, v, last)
+				last = v
+			}
+			assert.LessOrEqual(t, q.Size(), q.Cap())
+		}
+	}()
+
+	// with a single consumer, FIFO means strictly increasing
+	last := -1
+	for i := 0; ; i++ {
+		var v int
+		if i%2 == 0 {
+			var err error
+			if v, err = q.TryPop(ctx); err != nil {
+				assert.ErrorIs(t, err, queue.ErrQueueClosed)
+				break
+			}
+		} else {
+			var ok bool
+			if v, ok = q.Poll(); !ok {
+				continue
+			}
+		}
+		if !assert.Equal(t, last+1, v) {
+			break
+		}
+		last = v
+	}
+	close(stop)
+	<-peeked
+	assert.Equal(t, n-1, last)
+}
+
+func TestChannelEngineCloseWithSlot(t *testing.T) {
+	q := queue.NewArrayBlockingQueue[int](2, queue.WithEngine(queue.ChannelEngine))
+	assert.True(t, q.Offer(1))
+	_, ok := q.Peek()
+	assert.True(t, ok)
+	q.Close()
+	v, err := q.TryPop(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	_, err = q.TryPop(context.Background())
+	assert.ErrorIs(t, err, queue.ErrQueueClosed)
+
+	q = queue.NewArrayBlockingQueue[int](2, queue.WithEngine(queue.ChannelEngine))
+	assert.True(t, q.Offer(1))
+	assert.True(t, q.Offer(2))
+	_, ok = q.Peek()
+	assert.True(t, ok)
+	q.CloseNow()
+	assert.Zero(t, q.Size())
+	_, ok = q.Poll()
+	assert.False(t, ok)
+}
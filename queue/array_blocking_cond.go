@@ -0,0 +1,239 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue
+
+import (
+	"context"
+	"sync"
+)
+
+// condEngine is the ArrayBlockingQueue engine waiting on sync.Cond conditions.
+// A waiting goroutine registers its context to broadcast the condition once it ends.
+type condEngine[T any] struct {
+	items    []T
+	head     int
+	tail     int
+	size     int
+	closed   bool
+	lock     sync.Mutex
+	notEmpty sync.Cond
+	notFull  sync.Cond
+}
+
+var _ arrayEngine[int] = (*condEngine[int])(nil)
+
+func newCondEngine[T any](cap int) *condEngine[T] {
+	q := &condEngine[T]{items: make([]T, cap)}
+	q.notEmpty.L = &q.lock
+	q.notFull.L = &q.lock
+	return q
+}
+
+// wait waits on the condition until ready returns true, ctx is done or the queue is closed,
+// and returns the error of ctx if it ends first. The lock is held.
+// Like the semaphore engine, it fails with a done ctx even when the condition holds already.
+func (q *condEngine[T]) wait(ctx context.Context, c *sync.Cond, ready func() bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if ready() || q.closed {
+		return nil
+	}
+	stop := context.AfterFunc(ctx, func() {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+		c.Broadcast()
+	})
+	defer stop()
+	for !ready() && !q.closed {
+		if err := ctx.Err(); err != nil {
+			// The signal may have been for us, pass it on.
+			c.Signal()
+			return err
+		}
+		c.Wait()
+	}
+	return nil
+}
+
+func (q *condEngine[T]) hasRoom() bool {
+	return q.size < len(q.items)
+}
+
+func (q *condEngine[T]) hasItem() bool {
+	return q.size > 0
+}
+
+// Push waits for a free slot, then adds the element.
+func (q *condEngine[T]) Push(ctx context.Context, t T) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	if err := q.wait(ctx, &q.notFull, q.hasRoom); err != nil {
+		return err
+	}
+	if q.closed {
+		return ErrQueueClosed
+	}
+	q.put(t)
+	return nil
+}
+
+// TryPop waits for an element, then removes it.
+func (q *condEngine[T]) TryPop(ctx context.Context) (T, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	var zero T
+	if q.closed && q.size == 0 {
+		return zero, ErrQueueClosed
+	}
+	if err := q.wait(ctx, &q.notEmpty, q.hasItem); err != nil {
+		return zero, err
+	}
+	if q.size == 0 {
+		return zero, ErrQueueClosed
+	}
+	return q.take(), nil
+}
+
+// Offer adds the element if a slot is free right away.
+func (q *condEngine[T]) Offer(t T) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.closed || !q.hasRoom() {
+		return false
+	}
+	q.put(t)
+	return true
+}
+
+// PushAll waits for a free slot, then adds as many elements as there is room for under the lock.
+func (q *condEngine[T]) PushAll(ctx context.Context, ts ...T) (int, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	pushed := 0
+	for pushed < len(ts) {
+		if q.closed {
+			return pushed, ErrQueueClosed
+		}
+		if err := q.wait(ctx, &q.notFull, q.hasRoom); err != nil {
+			return pushed, err
+		}
+		if q.closed {
+			return pushed, ErrQueueClosed
+		}
+		for pushed < len(ts) && q.hasRoom() {
+			q.put(ts[pushed])
+			pushed++
+		}
+	}
+	return pushed, nil
+}
+
+// Poll removes an element if there is one right away.
+func (q *condEngine[T]) Poll() (T, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.take(), true
+}
+
+// Peek returns the element at the head.
+func (q *condEngine[T]) Peek() (T, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.items[q.head], true
+}
+
+// DrainTo removes the elements under one lock.
+func (q *condEngine[T]) DrainTo(dst []T, max int) []T {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	n := q.size
+	if max >= 0 {
+		n = min(n, max)
+	}
+	for i := 0; i < n; i++ {
+		dst = append(dst, q.take())
+	}
+	return dst
+}
+
+// put adds an element at the tail and signals a consumer, the lock held.
+func (q *condEngine[T]) put(t T) {
+	q.items[q.tail] = t
+	q.tail = (q.tail + 1) % len(q.items)
+	q.size++
+	q.notEmpty.Signal()
+}
+
+// take removes the element at the head and signals a producer, the lock held.
+func (q *condEngine[T]) take() T {
+	var zero T
+	t := q.items[q.head]
+	q.items[q.head] = zero
+	q.head = (q.head + 1) % len(q.items)
+	q.size--
+	q.notFull.Signal()
+	return t
+}
+
+// Close wakes all the waiting goroutines up.
+func (q *condEngine[T]) Close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.closed = true
+	q.notEmpty.Broadcast()
+	q.notFull.Broadcast()
+}
+
+func (q *condEngine[T]) CloseNow() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	clear(q.items)
+	q.head, q.tail, q.size = 0, 0, 0
+	q.closed = true
+	q.notEmpty.Broadcast()
+	q.notFull.Broadcast()
+}
+
+func (q *condEngine[T]) IsClosed() bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.closed
+}
+
+func (q *condEngine[T]) Size() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.size
+}
+
+func (q *condEngine[T]) Cap() int {
+	return len(q.items)
+}
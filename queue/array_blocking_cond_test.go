@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/queue"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCondEngineContextWakeUp(t *testing.T) {
+	q := queue.NewArrayBlockingQueue[int](1, queue.WithEngine(queue.CondEngine))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := q.TryPop(ctx)
+		done <- err
+	}()
+	time.Sleep(5 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("the cancelled TryPop is still waiting")
+	}
+}
+
+func TestCondEngineCancelledWaitersPassSignals(t *testing.T) {
+	q := queue.NewArrayBlockingQueue[int](8, queue.WithEngine(queue.CondEngine))
+	var wg sync.WaitGroup
+	// waiters giving up all the time, likely to get the signals first
+	ctx, cancel := context.WithCancel(context.Background())
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				popCtx, popCancel := context.WithTimeout(ctx, 50*time.Microsecond)
+				if v, err := q.TryPop(popCtx); err == nil {
+					// not meant to take any, put it back
+					assert.NoError(t, q.Push(context.Background(), v))
+				}
+				popCancel()
+			}
+		}()
+	}
+
+	results := make(chan int, 100)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				v, err := q.TryPop(context.Background())
+				if err != nil {
+					return
+				}
+				results <- v
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, q.Push(context.Background(), i))
+	}
+	assert.Eventually(t, func() bool { return len(results) == 100 }, 5*time.Second, time.Millisecond)
+	cancel()
+	q.Close()
+	wg.Wait()
+}
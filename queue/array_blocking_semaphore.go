@@ -0,0 +1,265 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// semaphoreEngine is the ArrayBlockingQueue engine counting the free slots with a semaphore, and the elements with a counter.
+type semaphoreEngine[T any] struct {
+	items    []T
+	cap      int
+	head     int
+	tail     int
+	size     int
+	lock     sync.Mutex
+	notEmpty counter
+	notFull  *semaphore.Weighted
+	closer   closer
+}
+
+var _ arrayEngine[int] = (*semaphoreEngine[int])(nil)
+
+func newSemaphoreEngine[T any](cap int) *semaphoreEngine[T] {
+	return &semaphoreEngine[T]{
+		items:   make([]T, cap),
+		cap:     cap,
+		notFull: semaphore.NewWeighted(int64(cap)),
+		closer:  newCloser(),
+	}
+}
+
+// Push waits for a free slot, then adds the element.
+func (q *semaphoreEngine[T]) Push(ctx context.Context, t T) error {
+	if q.closer.isClosed() {
+		return ErrQueueClosed
+	}
+	// Acquire a slot in the semaphore, blocking if necessary.
+	if err := q.closer.acquire(ctx, q.notFull); err != nil {
+		return err
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	// Check if the context has been cancelled, or the queue closed, when the lock is acquired.
+	if err := ctx.Err(); err != nil || q.closer.isClosed() {
+		// Release a slot for the notFull semaphore.
+		q.notFull.Release(1)
+		if err == nil {
+			err = ErrQueueClosed
+		}
+		return err
+	}
+
+	q.put(t)
+
+	// Count the element under the lock, see closer.
+	q.notEmpty.Release(1)
+
+	return nil
+}
+
+// TryPop waits for an element, then removes it.
+func (q *semaphoreEngine[T]) TryPop(ctx context.Context) (T, error) {
+	var zero T
+	// Acquire a slot in the semaphore, blocking if necessary.
+	if err := q.closer.acquireItem(ctx, &q.notEmpty); err != nil {
+		return zero, err
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	// Check if the context has already been cancelled when the lock is acquired.
+	if ctx.Err() != nil {
+		// Give the element back to the counter.
+		q.notEmpty.Release(1)
+		return zero, ctx.Err()
+	}
+	// The elements have been discarded by CloseNow.
+	if q.size == 0 {
+		return zero, ErrQueueClosed
+	}
+
+	t := q.take()
+
+	// Release a slot for the notFull semaphore.
+	q.notFull.Release(1)
+
+	return t, nil
+}
+
+// Offer adds the element if a slot is free right away.
+func (q *semaphoreEngine[T]) Offer(t T) bool {
+	if q.closer.isClosed() || !q.notFull.TryAcquire(1) {
+		return false
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.closer.isClosed() {
+		q.notFull.Release(1)
+		return false
+	}
+	q.put(t)
+	q.notEmpty.Release(1)
+	return true
+}
+
+// PushAll waits for a free slot, then takes all the others free,
+// to add as many elements as it can under one lock.
+func (q *semaphoreEngine[T]) PushAll(ctx context.Context, ts ...T) (int, error) {
+	pushed := 0
+	for pushed < len(ts) {
+		if q.closer.isClosed() {
+			return pushed, ErrQueueClosed
+		}
+		// Wait for a slot, then take all the others free.
+		if err := q.closer.acquire(ctx, q.notFull); err != nil {
+			return pushed, err
+		}
+		n := 1
+		for pushed+n < len(ts) && q.notFull.TryAcquire(1) {
+			n++
+		}
+
+		q.lock.Lock()
+		if err := ctx.Err(); err != nil || q.closer.isClosed() {
+			q.notFull.Release(int64(n))
+			q.lock.Unlock()
+			if err == nil {
+				err = ErrQueueClosed
+			}
+			return pushed, err
+		}
+		for _, t := range ts[pushed : pushed+n] {
+			q.put(t)
+		}
+		q.notEmpty.Release(int64(n))
+		q.lock.Unlock()
+		pushed += n
+	}
+	return pushed, nil
+}
+
+// Poll removes an element if there is one right away.
+func (q *semaphoreEngine[T]) Poll() (T, bool) {
+	var zero T
+	if !q.notEmpty.TryAcquire(1) {
+		return zero, false
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	// The elements have been discarded by CloseNow.
+	if q.size == 0 {
+		return zero, false
+	}
+	t := q.take()
+	q.notFull.Release(1)
+	return t, true
+}
+
+// Peek returns the element at the head.
+func (q *semaphoreEngine[T]) Peek() (T, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.items[q.head], true
+}
+
+// DrainTo removes the elements under one lock.
+func (q *semaphoreEngine[T]) DrainTo(dst []T, max int) []T {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	n := q.size
+	if max >= 0 {
+		n = min(n, max)
+	}
+	// Some slots may be held by pops waiting for the lock, take the others.
+	if n > 0 && !q.notEmpty.TryAcquire(int64(n)) {
+		k := 0
+		for k < n && q.notEmpty.TryAcquire(1) {
+			k++
+		}
+		n = k
+	}
+	for i := 0; i < n; i++ {
+		dst = append(dst, q.take())
+	}
+	if n > 0 {
+		q.notFull.Release(int64(n))
+	}
+	return dst
+}
+
+// put adds an element at the tail, the lock held.
+func (q *semaphoreEngine[T]) put(t T) {
+	q.items[q.tail] = t
+	q.tail = (q.tail + 1) % q.cap
+	q.size++
+}
+
+// take removes the element at the head, the lock held.
+func (q *semaphoreEngine[T]) take() T {
+	var zero T
+	t := q.items[q.head]
+	q.items[q.head] = zero
+	q.head = (q.head + 1) % q.cap
+	q.size--
+	return t
+}
+
+// Close takes the lock, so that the elements pushed are counted before the queue is seen closed.
+func (q *semaphoreEngine[T]) Close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.closer.close()
+}
+
+// CloseNow discards the elements, leaving their slots taken.
+func (q *semaphoreEngine[T]) CloseNow() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.closer.close()
+	clear(q.items)
+	q.head, q.tail, q.size = 0, 0, 0
+}
+
+func (q *semaphoreEngine[T]) IsClosed() bool {
+	return q.closer.isClosed()
+}
+
+func (q *semaphoreEngine[T]) Size() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.size
+}
+
+func (q *semaphoreEngine[T]) Cap() int {
+	return q.cap
+}
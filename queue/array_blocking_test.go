@@ -20,6 +20,9 @@ package queue_test
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -150,3 +153,250 @@ func TestArrayBlockingQueueRacePop(t *testing.T) {
 	assert.Greater(t, q.Size(), 0)
 	// usually the result is greater than 0, like 675
 }
+
+// engines are the engines of ArrayBlockingQueue.
+var engines = []queue.Engine{queue.SemaphoreEngine, queue.CondEngine, queue.ChannelEngine}
+
+func TestArrayBlockingQueueOfferPoll(t *testing.T) {
+	for _, e := range engines {
+		t.Run(e.String(), func(t *testing.T) {
+			q := queue.NewArrayBlockingQueue[int](2, queue.WithEngine(e))
+			_, ok := q.Poll()
+			assert.False(t, ok)
+			_, ok = q.Peek()
+			assert.False(t, ok)
+
+			assert.True(t, q.Offer(1))
+			assert.True(t, q.Offer(2))
+			assert.False(t, q.Offer(3))
+			assert.Equal(t, 2, q.Size())
+
+			v, ok := q.Peek()
+			assert.True(t, ok)
+			assert.Equal(t, 1, v)
+			assert.Equal(t, 2, q.Size())
+
+			v, ok = q.Poll()
+			assert.True(t, ok)
+			assert.Equal(t, 1, v)
+			assert.True(t, q.Offer(3))
+
+			q.Close()
+			assert.False(t, q.Offer(4))
+			for _, want := range []int{2, 3} {
+				v, ok = q.Poll()
+				assert.True(t, ok)
+				assert.Equal(t, want, v)
+			}
+			_, ok = q.Poll()
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestArrayBlockingQueueCancelledContext(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, e := range engines {
+		t.Run(e.String(), func(t *testing.T) {
+			// a done context fails even when the queue is ready
+			q := queue.NewArrayBlockingQueue[int](2, queue.WithEngine(e))
+			assert.ErrorIs(t, q.Push(cancelled, 1), context.Canceled)
+			n, err := q.PushAll(cancelled, 1, 2)
+			assert.Zero(t, n)
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.Zero(t, q.Size())
+
+			assert.True(t, q.Offer(1))
+			_, err = q.TryPop(cancelled)
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.Equal(t, 1, q.Size())
+
+			// while a closed queue fails pushes, and pops once drained, with ErrQueueClosed
+			q.Close()
+			assert.ErrorIs(t, q.Push(cancelled, 2), queue.ErrQueueClosed)
+			_, err = q.PushAll(cancelled, 2)
+			assert.ErrorIs(t, err, queue.ErrQueueClosed)
+			_, err = q.TryPop(cancelled)
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.Equal(t, []int{1}, q.DrainTo(nil, -1))
+			_, err = q.TryPop(cancelled)
+			assert.ErrorIs(t, err, queue.ErrQueueClosed)
+		})
+	}
+}
+
+func TestArrayBlockingQueuePushAll(t *testing.T) {
+	for _, e := range engines {
+		t.Run(e.String(), func(t *testing.T) {
+			q := queue.NewArrayBlockingQueue[int](3, queue.WithEngine(e))
+			n, err := q.PushAll(context.Background())
+			assert.NoError(t, err)
+			assert.Zero(t, n)
+
+			// more elements than the capacity: PushAll waits for the consumer
+			done := make(chan struct{})
+			var got []int
+			go func() {
+				defer close(done)
+				for range 10 {
+					v, err := q.TryPop(context.Background())
+					assert.NoError(t, err)
+					got = append(got, v)
+				}
+			}()
+			n, err = q.PushAll(context.Background(), 0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+			assert.NoError(t, err)
+			assert.Equal(t, 10, n)
+			<-done
+			assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, got)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			n, err = q.PushAll(ctx, 0, 1, 2, 3, 4)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+			assert.Equal(t, 3, n)
+
+			q.Close()
+			n, err = q.PushAll(context.Background(), 5)
+			assert.ErrorIs(t, err, queue.ErrQueueClosed)
+			assert.Zero(t, n)
+		})
+	}
+}
+
+func TestArrayBlockingQueueDrainTo(t *testing.T) {
+	for _, e := range engines {
+		t.Run(e.String(), func(t *testing.T) {
+			q := queue.NewArrayBlockingQueue[int](8, queue.WithEngine(e))
+			assert.Empty(t, q.DrainTo(nil, -1))
+			for i := range 6 {
+				assert.True(t, q.Offer(i))
+			}
+
+			buf := make([]int, 0, 4)
+			buf = q.DrainTo(buf, 4)
+			assert.Equal(t, []int{0, 1, 2, 3}, buf)
+			assert.Equal(t, 2, q.Size())
+			assert.Empty(t, q.DrainTo(buf[:0], 0))
+
+			buf = q.DrainTo(buf[:1], -1)
+			assert.Equal(t, []int{0, 4, 5}, buf)
+			assert.Zero(t, q.Size())
+
+			// the drained slots are free again
+			for i := range 8 {
+				assert.True(t, q.Offer(i))
+			}
+			assert.False(t, q.Offer(8))
+		})
+	}
+}
+
+func TestArrayBlockingQueueDrainRace(t *testing.T) {
+	for _, e := range engines {
+		t.Run(e.String(), func(t *testing.T) {
+			q := queue.NewArrayBlockingQueue[int](16, queue.WithEngine(e))
+			const n = 20000
+			go func() {
+				batch := make([]int, 0, 7)
+				for i := 0; i < n; i += len(batch) {
+					batch = batch[:0]
+					for j := i; j < min(i+7, n); j++ {
+						batch = append(batch, j)
+					}
+					_, err := q.PushAll(context.Background(), batch...)
+					assert.NoError(t, err)
+				}
+				q.Close()
+			}()
+
+			var mu sync.Mutex
+			var got []int
+			var wg sync.WaitGroup
+			for c := 0; c < 4; c++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					buf := make([]int, 0, 5)
+					for {
+						// wait for an element, then take what is there along with it
+						v, err := q.TryPop(context.Background())
+						if err != nil {
+							assert.ErrorIs(t, err, queue.ErrQueueClosed)
+							return
+						}
+						buf = append(buf[:0], v)
+						if c%2 == 0 {
+							buf = q.DrainTo(buf, 4)
+						} else if v, ok := q.Poll(); ok {
+							buf = append(buf, v)
+						}
+						mu.Lock()
+						got = append(got, buf...)
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+			sort.Ints(got)
+			assert.Len(t, got, n)
+			for i, v := range got {
+				assert.Equal(t, i, v)
+			}
+		})
+	}
+}
+
+func BenchmarkArrayBlockingQueue(b *testing.B) {
+	for _, e := range engines {
+		for _, capacity := range []int{1, 16, 1024} {
+			for _, pc := range [][2]int{{1, 1}, {1, 8}, {8, 1}, {8, 8}} {
+				producers, consumers := pc[0], pc[1]
+				name := fmt.Sprintf("%s/cap=%d/p=%d/c=%d", e, capacity, producers, consumers)
+				b.Run(name, func(b *testing.B) {
+					benchmarkArrayBlockingQueue(b, queue.NewArrayBlockingQueue[int](capacity, queue.WithEngine(e)), producers, consumers)
+				})
+			}
+		}
+	}
+}
+
+// benchmarkArrayBlockingQueue passes b.N elements from the producers to the consumers.
+func benchmarkArrayBlockingQueue(b *testing.B, q *queue.ArrayBlockingQueue[int], producers, consumers int) {
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := p; i < b.N; i += producers {
+				_ = q.Push(ctx, i)
+			}
+		}()
+	}
+	for c := 0; c < consumers; c++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := c; i < b.N; i += consumers {
+				_, _ = q.TryPop(ctx)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkArrayBlockingQueueDrainTo(b *testing.B) {
+	for _, e := range engines {
+		b.Run(e.String(), func(b *testing.B) {
+			q := queue.NewArrayBlockingQueue[int](1024, queue.WithEngine(e))
+			batch := make([]int, 64)
+			buf := make([]int, 0, 64)
+			for i := 0; i < b.N; i += 64 {
+				_, _ = q.PushAll(context.Background(), batch...)
+				buf = q.DrainTo(buf[:0], 64)
+			}
+		})
+	}
+}
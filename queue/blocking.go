@@ -18,7 +18,16 @@
  */
 package queue
 
-import "context"
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/mizumoto-cn/fpkit/internal/err"
+)
+
+// ErrQueueClosed is returned by the operations of a closed BlockingQueue.
+var ErrQueueClosed = err.ErrQueueClosed
 
 // BlockingQueue is a generic interface for a blocking queue.
 // Blocking queues are not always FIFO, it depends on the implementation.
@@ -26,10 +35,165 @@ type BlockingQueue[T any] interface {
 	// Push adds an element to the the queue.
 	// when cancelled or timeout, return context.Canceled or context.DeadlineExceeded
 	// Shall always use errors.Is(err, context.Canceled) or errors.Is(err, context.DeadlineExceeded) to check the error
+	// Once the queue is closed, return ErrQueueClosed
 	Push(ctx context.Context, t T) error
 
 	// TryPop removes and returns a element in the queue.
 	// when cancelled or timeout, return context.Canceled or context.DeadlineExceeded
 	// Shall always use errors.Is(err, context.Canceled) or errors.Is(err, context.DeadlineExceeded) to check the error
+	// Once the queue is closed and drained, return ErrQueueClosed
 	TryPop(ctx context.Context) (T, error)
+
+	// Close closes the queue: the pending and later pushes fail,
+	// the pops drain the remaining elements, then fail.
+	// Closing a closed queue does nothing.
+	Close()
+
+	// CloseNow closes the queue and discards its elements.
+	CloseNow()
+
+	// IsClosed reports whether the queue has been closed.
+	IsClosed() bool
+}
+
+// closer is the closed state of a blocking queue, waking up the goroutines waiting on it.
+//
+// A queue makes a pushed element poppable, e.g. releases its element counter, under the lock Close takes.
+// So once the queue is seen closed, every element pushed before is poppable,
+// and the pops drain them with acquireItem before failing with ErrQueueClosed.
+type closer struct {
+	// ctx is done once the queue is closed
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newCloser() closer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return closer{ctx: ctx, cancel: cancel}
+}
+
+func (c *closer) close() {
+	c.cancel()
+}
+
+func (c *closer) isClosed() bool {
+	return c.ctx.Err() != nil
+}
+
+// permits is a semaphore the goroutines of a blocking queue wait on:
+// a semaphore.Weighted for the free slots, or a counter for the elements.
+type permits interface {
+	Acquire(ctx context.Context, n int64) error
+	TryAcquire(n int64) bool
+	Release(n int64)
+}
+
+// acquire takes a permit, blocking until there is one, ctx is done,
+// or the queue is closed, then returning ErrQueueClosed.
+func (c *closer) acquire(ctx context.Context, sem permits) error {
+	if sem.TryAcquire(1) {
+		return nil
+	}
+	if c.isClosed() {
+		return ErrQueueClosed
+	}
+	waitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(c.ctx, cancel)
+	defer stop()
+	if e := sem.Acquire(waitCtx, 1); e != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return ErrQueueClosed
+	}
+	return nil
+}
+
+// acquireItem is acquire on the counter of the elements: once the queue is closed,
+// it still takes the permits left, for the pops to drain the queue.
+func (c *closer) acquireItem(ctx context.Context, sem permits) error {
+	e := c.acquire(ctx, sem)
+	if e == ErrQueueClosed && sem.TryAcquire(1) {
+		return nil
+	}
+	return e
+}
+
+// counter is a semaphore that starts with no permits and has no maximum,
+// to count the elements of a blocking queue. The waiters are served in FIFO order.
+type counter struct {
+	lock    sync.Mutex
+	n       int64
+	waiters list.List
+}
+
+// counterWaiter is a goroutine waiting for n permits of a counter, ready is closed once it has them.
+type counterWaiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+// Acquire takes n permits, blocking until they are released or ctx is done.
+// If ctx is done, it takes none and returns ctx.Err().
+func (c *counter) Acquire(ctx context.Context, n int64) error {
+	c.lock.Lock()
+	if c.n >= n && c.waiters.Len() == 0 {
+		c.n -= n
+		c.lock.Unlock()
+		return nil
+	}
+	w := counterWaiter{n: n, ready: make(chan struct{})}
+	elem := c.waiters.PushBack(w)
+	c.lock.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		select {
+		case <-w.ready:
+			// Served meanwhile, give the permits back.
+			c.n += n
+		default:
+			c.waiters.Remove(elem)
+		}
+		// The next waiters may be served now.
+		c.serve()
+		return ctx.Err()
+	}
+}
+
+// TryAcquire takes n permits if they are there, and no one is waiting for them.
+func (c *counter) TryAcquire(n int64) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.n < n || c.waiters.Len() > 0 {
+		return false
+	}
+	c.n -= n
+	return true
+}
+
+// Release adds n permits, serving the waiters.
+func (c *counter) Release(n int64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.n += n
+	c.serve()
+}
+
+// serve hands the permits to the waiters in order, the lock held.
+func (c *counter) serve() {
+	for e := c.waiters.Front(); e != nil; e = c.waiters.Front() {
+		w := e.Value.(counterWaiter)
+		if c.n < w.n {
+			return
+		}
+		c.n -= w.n
+		c.waiters.Remove(e)
+		close(w.ready)
+	}
 }
@@ -0,0 +1,245 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/queue"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingQueues are the BlockingQueue implementations, bounded to cap when they can be.
+var blockingQueues = map[string]func(cap int) queue.BlockingQueue[int]{
+	"ArrayBlockingQueue": func(cap int) queue.BlockingQueue[int] {
+		return queue.NewArrayBlockingQueue[int](cap)
+	},
+	"ArrayBlockingQueue/cond": func(cap int) queue.BlockingQueue[int] {
+		return queue.NewArrayBlockingQueue[int](cap, queue.WithEngine(queue.CondEngine))
+	},
+	"ArrayBlockingQueue/channel": func(cap int) queue.BlockingQueue[int] {
+		return queue.NewArrayBlockingQueue[int](cap, queue.WithEngine(queue.ChannelEngine))
+	},
+	"ChanQueue": func(cap int) queue.BlockingQueue[int] {
+		return queue.NewChanQueue[int](cap)
+	},
+	"LinkedBlockingQueue": func(cap int) queue.BlockingQueue[int] {
+		return queue.NewLinkedBlockingQueue[int](cap)
+	},
+	"PriorityBlockingQueue": func(cap int) queue.BlockingQueue[int] {
+		return queue.NewPriorityBlockingQueue[int](functional.Less[int], cap)
+	},
+	"DelayQueue": func(int) queue.BlockingQueue[int] {
+		return queue.NewDelayQueue[int]()
+	},
+}
+
+func TestBlockingQueueClose(t *testing.T) {
+	for name, newQueue := range blockingQueues {
+		t.Run(name, func(t *testing.T) {
+			q := newQueue(4)
+			ctx := context.Background()
+			assert.False(t, q.IsClosed())
+			for i := 0; i < 3; i++ {
+				assert.NoError(t, q.Push(ctx, i))
+			}
+			q.Close()
+			q.Close()
+			assert.True(t, q.IsClosed())
+			assert.ErrorIs(t, q.Push(ctx, 3), queue.ErrQueueClosed)
+
+			// the remaining elements are drained first
+			for i := 0; i < 3; i++ {
+				v, err := q.TryPop(ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, i, v)
+			}
+			_, err := q.TryPop(ctx)
+			assert.ErrorIs(t, err, queue.ErrQueueClosed)
+		})
+	}
+}
+
+func TestBlockingQueueCloseNow(t *testing.T) {
+	for name, newQueue := range blockingQueues {
+		t.Run(name, func(t *testing.T) {
+			q := newQueue(4)
+			ctx := context.Background()
+			for i := 0; i < 3; i++ {
+				assert.NoError(t, q.Push(ctx, i))
+			}
+			q.CloseNow()
+			assert.True(t, q.IsClosed())
+			_, err := q.TryPop(ctx)
+			assert.ErrorIs(t, err, queue.ErrQueueClosed)
+			assert.ErrorIs(t, q.Push(ctx, 3), queue.ErrQueueClosed)
+		})
+	}
+}
+
+func TestBlockingQueueCloseWakesPops(t *testing.T) {
+	for name, newQueue := range blockingQueues {
+		t.Run(name, func(t *testing.T) {
+			q := newQueue(4)
+			var wg sync.WaitGroup
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := q.TryPop(context.Background())
+					assert.ErrorIs(t, err, queue.ErrQueueClosed)
+				}()
+			}
+			time.Sleep(10 * time.Millisecond)
+			q.Close()
+			wg.Wait()
+		})
+	}
+}
+
+func TestBlockingQueueCloseWakesPushes(t *testing.T) {
+	for name, newQueue := range blockingQueues {
+		if name == "DelayQueue" {
+			// unbounded, pushes never wait
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			q := newQueue(1)
+			assert.NoError(t, q.Push(context.Background(), 0))
+			var wg sync.WaitGroup
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					assert.ErrorIs(t, q.Push(context.Background(), 1), queue.ErrQueueClosed)
+				}()
+			}
+			time.Sleep(10 * time.Millisecond)
+			q.Close()
+			wg.Wait()
+
+			v, err := q.TryPop(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, 0, v)
+		})
+	}
+}
+
+func TestBlockingQueueCloseRace(t *testing.T) {
+	for name, newQueue := range blockingQueues {
+		t.Run(name, func(t *testing.T) {
+			q := newQueue(8)
+			var pushed, popped sync.Map
+			var wg sync.WaitGroup
+			for p := 0; p < 4; p++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := p * 1000; ; i++ {
+						if q.Push(context.Background(), i) != nil {
+							return
+						}
+						pushed.Store(i, true)
+					}
+				}()
+			}
+			for c := 0; c < 4; c++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for {
+						v, err := q.TryPop(context.Background())
+						if err != nil {
+							assert.ErrorIs(t, err, queue.ErrQueueClosed)
+							return
+						}
+						popped.Store(v, true)
+					}
+				}()
+			}
+			time.Sleep(5 * time.Millisecond)
+			q.Close()
+			wg.Wait()
+
+			// every element pushed before closing is popped
+			pushed.Range(func(k, _ any) bool {
+				_, ok := popped.Load(k)
+				assert.True(t, ok, "lost %v", k)
+				return true
+			})
+		})
+	}
+}
+
+func TestBlockingQueueContextFirst(t *testing.T) {
+	for name, newQueue := range blockingQueues {
+		t.Run(name, func(t *testing.T) {
+			q := newQueue(4)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+			defer cancel()
+			_, err := q.TryPop(ctx)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+			assert.False(t, q.IsClosed())
+		})
+	}
+}
+
+func TestBlockingQueueCancelledPopKeepsElements(t *testing.T) {
+	for name, newQueue := range blockingQueues {
+		t.Run(name, func(t *testing.T) {
+			q := newQueue(4)
+			// waiting pops that give up take nothing
+			var wg sync.WaitGroup
+			for range 3 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+					defer cancel()
+					_, err := q.TryPop(ctx)
+					assert.ErrorIs(t, err, context.DeadlineExceeded)
+				}()
+			}
+			wg.Wait()
+
+			popped := make(chan int)
+			go func() {
+				v, err := q.TryPop(context.Background())
+				assert.NoError(t, err)
+				popped <- v
+			}()
+			assert.NoError(t, q.Push(context.Background(), 1))
+			assert.NoError(t, q.Push(context.Background(), 2))
+			select {
+			case v := <-popped:
+				assert.Equal(t, 1, v)
+			case <-time.After(time.Second):
+				t.Fatal("the waiting pop missed the element")
+			}
+			v, err := q.TryPop(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, 2, v)
+		})
+	}
+}
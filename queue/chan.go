@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue
+
+import "context"
+
+// ToChan returns a channel receiving the elements popped from the queue,
+// closed once the queue is closed and drained, or ctx is done.
+// An element popped when ctx ends is still sent before the channel is closed, so none is lost:
+// receive until the channel is closed, or the goroutine sending it is left blocked.
+//
+//	jobs := queue.ToChan(ctx, q)
+//	select {
+//	case job, ok := <-jobs:
+//		...
+//	case <-ticker.C:
+//		...
+//	}
+func ToChan[T any](ctx context.Context, q BlockingQueue[T]) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			v, err := q.TryPop(ctx)
+			if err != nil {
+				return
+			}
+			out <- v
+		}
+	}()
+	return out
+}
+
+// FromChan pushes the elements received from the channel to the queue, until the channel is closed.
+// The returned channel receives the error of Push, or of ctx if it ends first,
+// and is closed once FromChan is done. The queue is left open.
+func FromChan[T any](ctx context.Context, ch <-chan T, q BlockingQueue[T]) <-chan error {
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		for {
+			select {
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return
+			case v, ok := <-ch:
+				if !ok {
+					return
+				}
+				if err := q.Push(ctx, v); err != nil {
+					errc <- err
+					return
+				}
+			}
+		}
+	}()
+	return errc
+}
+
+// ChanQueue is a BlockingQueue on a buffered channel, which it exposes to select on.
+// As with any channel, sending to In once the queue is closed panics:
+// the producers using In must be done before Close.
+//
+//	q := queue.NewChanQueue[int](16)
+//	select {
+//	case q.In() <- 1:
+//	case <-ctx.Done():
+//	}
+type ChanQueue[T any] struct {
+	engine *channelEngine[T]
+}
+
+var _ BlockingQueue[int] = (*ChanQueue[int])(nil)
+
+// NewChanQueue creates a new ChanQueue holding at most cap elements.
+func NewChanQueue[T any](cap int) *ChanQueue[T] {
+	return &ChanQueue[T]{engine: newChannelEngine[T](cap, false)}
+}
+
+// In returns the channel to send the elements to.
+func (q *ChanQueue[T]) In() chan<- T {
+	return q.engine.items
+}
+
+// Out returns the channel to receive the elements from, closed once the queue is closed and drained.
+func (q *ChanQueue[T]) Out() <-chan T {
+	return q.engine.items
+}
+
+// Push adds an element to the queue.
+// When cancelled or timeout, return context.Canceled or context.DeadlineExceeded.
+// Shall always use errors.Is(err, context.Canceled) or errors.Is(err, context.DeadlineExceeded) to check the error.
+// Once the queue is closed, return ErrQueueClosed.
+func (q *ChanQueue[T]) Push(ctx context.Context, t T) error {
+	return q.engine.Push(ctx, t)
+}
+
+// TryPop removes and returns an element from the queue.
+// When cancelled or timeout, return context.Canceled or context.DeadlineExceeded.
+// Shall always use errors.Is(err, context.Canceled) or errors.Is(err, context.DeadlineExceeded) to check the error.
+// Once the queue is closed and drained, return ErrQueueClosed.
+func (q *ChanQueue[T]) TryPop(ctx context.Context) (T, error) {
+	return q.engine.TryPop(ctx)
+}
+
+// Offer adds an element to the queue if there is room, without blocking.
+// It returns false if the queue is full or closed.
+func (q *ChanQueue[T]) Offer(t T) bool {
+	return q.engine.Offer(t)
+}
+
+// Poll removes and returns the first element of the queue, without blocking.
+// It returns false if the queue is empty.
+func (q *ChanQueue[T]) Poll() (T, bool) {
+	return q.engine.Poll()
+}
+
+// Close closes the queue, and the channel: the pending and later pushes fail with ErrQueueClosed,
+// the pops drain the remaining elements, then fail with ErrQueueClosed.
+func (q *ChanQueue[T]) Close() {
+	q.engine.Close()
+}
+
+// CloseNow closes the queue and discards its elements.
+func (q *ChanQueue[T]) CloseNow() {
+	q.engine.CloseNow()
+}
+
+// IsClosed reports whether the queue has been closed.
+func (q *ChanQueue[T]) IsClosed() bool {
+	return q.engine.IsClosed()
+}
+
+// Size returns the number of elements in the queue, at the time of calling.
+func (q *ChanQueue[T]) Size() int {
+	return q.engine.Size()
+}
+
+// Cap returns the capacity of the queue.
+func (q *ChanQueue[T]) Cap() int {
+	return q.engine.Cap()
+}
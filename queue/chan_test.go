@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/queue"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToChan(t *testing.T) {
+	q := queue.NewLinkedBlockingQueue[int](0)
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, q.Push(context.Background(), i))
+	}
+	q.Close()
+	var got []int
+	for v := range queue.ToChan[int](context.Background(), q) {
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, got)
+
+	// ending ctx closes the channel too
+	q = queue.NewLinkedBlockingQueue[int](0)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := queue.ToChan[int](ctx, q)
+	assert.NoError(t, q.Push(context.Background(), 1))
+	timer := time.NewTimer(time.Second)
+	defer timer.Stop()
+	select {
+	case v := <-ch:
+		assert.Equal(t, 1, v)
+	case <-timer.C:
+		t.Fatal("no element")
+	}
+	cancel()
+	_, ok := <-ch
+	assert.False(t, ok)
+
+	// an element popped when ctx ends is still sent, in order
+	q = queue.NewLinkedBlockingQueue[int](0)
+	assert.NoError(t, q.Push(context.Background(), 1))
+	assert.NoError(t, q.Push(context.Background(), 2))
+	ctx, cancel = context.WithCancel(context.Background())
+	ch = queue.ToChan[int](ctx, q)
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	got = nil
+	for v := range ch {
+		got = append(got, v)
+	}
+	q.Close()
+	for v := range queue.ToChan[int](context.Background(), q) {
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{1, 2}, got)
+}
+
+func TestFromChan(t *testing.T) {
+	q := queue.NewArrayBlockingQueue[int](8)
+	ch := make(chan int)
+	errc := queue.FromChan(context.Background(), ch, q)
+	for i := 0; i < 5; i++ {
+		ch <- i
+	}
+	close(ch)
+	assert.NoError(t, <-errc)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, q.DrainTo(nil, -1))
+	assert.False(t, q.IsClosed())
+
+	// the error of Push
+	q.Close()
+	ch = make(chan int, 1)
+	ch <- 5
+	assert.ErrorIs(t, <-queue.FromChan(context.Background(), ch, q), queue.ErrQueueClosed)
+
+	// the error of ctx
+	ctx, cancel := context.WithCancel(context.Background())
+	errc = queue.FromChan(ctx, make(chan int), queue.NewArrayBlockingQueue[int](1))
+	cancel()
+	assert.ErrorIs(t, <-errc, context.Canceled)
+}
+
+func TestChanQueue(t *testing.T) {
+	q := queue.NewChanQueue[string](2)
+	assert.Equal(t, 2, q.Cap())
+
+	q.In() <- "a"
+	assert.NoError(t, q.Push(context.Background(), "b"))
+	assert.Equal(t, 2, q.Size())
+	assert.False(t, q.Offer("c"))
+
+	timer := time.NewTimer(time.Second)
+	defer timer.Stop()
+	select {
+	case v := <-q.Out():
+		assert.Equal(t, "a", v)
+	case <-timer.C:
+		t.Fatal("no element")
+	}
+	v, ok := q.Poll()
+	assert.True(t, ok)
+	assert.Equal(t, "b", v)
+
+	// select on sending, along with a deadline
+	full := time.After(10 * time.Millisecond)
+	sent := 0
+	for sending := true; sending; {
+		select {
+		case q.In() <- "x":
+			sent++
+		case <-full:
+			sending = false
+		}
+	}
+	assert.Equal(t, 2, sent)
+
+	q.Close()
+	var rest []string
+	for v := range q.Out() {
+		rest = append(rest, v)
+	}
+	assert.Equal(t, []string{"x", "x"}, rest)
+	_, err := q.TryPop(context.Background())
+	assert.ErrorIs(t, err, queue.ErrQueueClosed)
+}
@@ -0,0 +1,254 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/internal/err"
+)
+
+// Clock tells the time to a DelayQueue, so it can run in simulated time.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel receiving the time once d has elapsed,
+	// and a function to stop the timer.
+	After(d time.Duration) (<-chan time.Time, func())
+}
+
+// SystemClock is the Clock of the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) (<-chan time.Time, func()) {
+	t := time.NewTimer(d)
+	return t.C, func() { t.Stop() }
+}
+
+// delayed is an element of a DelayQueue with its ready time.
+// seq keeps the elements ready at the same time in FIFO order.
+type delayed[T any] struct {
+	value T
+	at    time.Time
+	seq   uint64
+}
+
+// before reports whether a is ready before b.
+func (a delayed[T]) before(b delayed[T]) bool {
+	if a.at.Equal(b.at) {
+		return a.seq < b.seq
+	}
+	return a.at.Before(b.at)
+}
+
+// DelayQueue is a thread-safe unbounded queue where each element becomes ready at its own time.
+// TryPop blocks until the earliest element is ready.
+//
+//	q := queue.NewDelayQueue[string]()
+//	_ = q.PushAfter("retry", 5*time.Second)
+//	v, _ := q.TryPop(ctx) // "retry", 5 seconds later
+type DelayQueue[T any] struct {
+	clock Clock
+	lock  sync.Mutex
+	heap  []delayed[T]
+	seq   uint64
+	// wake is closed and replaced when the earliest element changes, or the queue is closed
+	wake   chan struct{}
+	closed bool
+}
+
+var _ BlockingQueue[int] = (*DelayQueue[int])(nil)
+
+// NewDelayQueue creates a new DelayQueue on the SystemClock.
+func NewDelayQueue[T any]() *DelayQueue[T] {
+	return NewDelayQueueWithClock[T](SystemClock)
+}
+
+// NewDelayQueueWithClock creates a new DelayQueue telling the time with the clock.
+func NewDelayQueueWithClock[T any](clock Clock) *DelayQueue[T] {
+	return &DelayQueue[T]{clock: clock, wake: make(chan struct{})}
+}
+
+// Push adds an element ready right away.
+// The queue is unbounded, Push never blocks.
+// Once the queue is closed, return ErrQueueClosed.
+func (q *DelayQueue[T]) Push(_ context.Context, t T) error {
+	return q.PushAt(t, q.clock.Now())
+}
+
+// PushAfter adds an element ready once the delay has elapsed.
+// The delay must not be negative, otherwise it will return an error.
+func (q *DelayQueue[T]) PushAfter(t T, delay time.Duration) error {
+	if delay < 0 {
+		return err.NewInvalidTimeIntervalError(delay)
+	}
+	return q.PushAt(t, q.clock.Now().Add(delay))
+}
+
+// PushAt adds an element ready at the given time.
+// Once the queue is closed, return ErrQueueClosed.
+func (q *DelayQueue[T]) PushAt(t T, at time.Time) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	q.heap = append(q.heap, delayed[T]{value: t, at: at, seq: q.seq})
+	q.seq++
+	if q.up(len(q.heap)-1) == 0 {
+		// A new earliest element, the waiting consumers must re-arm their timers
+		q.wakeUp()
+	}
+	return nil
+}
+
+// wakeUp wakes the waiting consumers up, the lock held.
+func (q *DelayQueue[T]) wakeUp() {
+	close(q.wake)
+	q.wake = make(chan struct{})
+}
+
+// TryPop removes and returns the earliest element, blocking until it is ready.
+// When cancelled or timeout, return context.Canceled or context.DeadlineExceeded.
+// Shall always use errors.Is(err, context.Canceled) or errors.Is(err, context.DeadlineExceeded) to check the error.
+// Once the queue is closed, the remaining elements are still popped when ready,
+// then return ErrQueueClosed.
+func (q *DelayQueue[T]) TryPop(ctx context.Context) (T, error) {
+	for {
+		q.lock.Lock()
+		wake := q.wake
+		var timer <-chan time.Time
+		stop := func() {}
+		if len(q.heap) > 0 {
+			wait := q.heap[0].at.Sub(q.clock.Now())
+			if wait <= 0 {
+				t := q.pop()
+				q.lock.Unlock()
+				return t, nil
+			}
+			timer, stop = q.clock.After(wait)
+		} else if q.closed {
+			q.lock.Unlock()
+			var zero T
+			return zero, ErrQueueClosed
+		}
+		q.lock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			stop()
+			var zero T
+			return zero, ctx.Err()
+		case <-wake:
+			stop()
+		case <-timer:
+		}
+	}
+}
+
+// pop removes the earliest element, the lock held.
+func (q *DelayQueue[T]) pop() T {
+	t := q.heap[0].value
+	last := len(q.heap) - 1
+	q.heap[0] = q.heap[last]
+	// Let the popped element go
+	q.heap[last] = delayed[T]{}
+	q.heap = q.heap[:last]
+	q.down(0)
+	return t
+}
+
+// up moves the element at index i up the heap to its correct position, and returns that position.
+func (q *DelayQueue[T]) up(i int) int {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.heap[i].before(q.heap[parent]) {
+			break
+		}
+		q.heap[parent], q.heap[i] = q.heap[i], q.heap[parent]
+		i = parent
+	}
+	return i
+}
+
+// down moves the element at index i down the heap to its correct position.
+func (q *DelayQueue[T]) down(i int) {
+	n := len(q.heap)
+	for {
+		j := 2*i + 1
+		if j >= n {
+			break
+		}
+		if right := j + 1; right < n && q.heap[right].before(q.heap[j]) {
+			j = right
+		}
+		if !q.heap[j].before(q.heap[i]) {
+			break
+		}
+		q.heap[i], q.heap[j] = q.heap[j], q.heap[i]
+		i = j
+	}
+}
+
+// Close closes the queue: the later pushes fail with ErrQueueClosed,
+// the pops drain the remaining elements as they get ready, then fail with ErrQueueClosed.
+func (q *DelayQueue[T]) Close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if !q.closed {
+		q.closed = true
+		q.wakeUp()
+	}
+}
+
+// CloseNow closes the queue and discards its elements.
+func (q *DelayQueue[T]) CloseNow() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	clear(q.heap)
+	q.heap = q.heap[:0]
+	q.closed = true
+	q.wakeUp()
+}
+
+// IsClosed reports whether the queue has been closed.
+func (q *DelayQueue[T]) IsClosed() bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.closed
+}
+
+// Size returns the number of elements in the queue, ready or not, at the time of calling.
+func (q *DelayQueue[T]) Size() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return len(q.heap)
+}
+
+// Cap returns -1, as the queue is unbounded.
+func (q *DelayQueue[T]) Cap() int {
+	return -1
+}
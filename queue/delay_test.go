@@ -0,0 +1,241 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/internal/err"
+	"github.com/mizumoto-cn/fpkit/queue"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a Clock only moving forward on Advance.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers map[*fakeTimer]struct{}
+}
+
+type fakeTimer struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0), timers: make(map[*fakeTimer]struct{})}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) (<-chan time.Time, func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{at: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.timers[t] = struct{}{}
+	return t.c, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.timers, t)
+	}
+}
+
+// Advance moves the time forward, firing the timers due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for t := range c.timers {
+		if !t.at.After(c.now) {
+			t.c <- c.now
+			delete(c.timers, t)
+		}
+	}
+}
+
+// pending returns the number of armed timers.
+func (c *fakeClock) pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// popAsync pops in the background.
+func popAsync[T any](q *queue.DelayQueue[T]) <-chan T {
+	out := make(chan T, 1)
+	go func() {
+		v, e := q.TryPop(context.Background())
+		if e == nil {
+			out <- v
+		}
+	}()
+	return out
+}
+
+func assertBlocked[T any](t *testing.T, c <-chan T) {
+	select {
+	case v := <-c:
+		t.Fatalf("popped %v too early", v)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestDelayQueueOrder(t *testing.T) {
+	clock := newFakeClock()
+	q := queue.NewDelayQueueWithClock[string](clock)
+	assert.Equal(t, -1, q.Cap())
+	assert.NoError(t, q.PushAfter("c", 3*time.Second))
+	assert.NoError(t, q.PushAfter("a", time.Second))
+	assert.NoError(t, q.PushAfter("b", 2*time.Second))
+	assert.NoError(t, q.PushAfter("b2", 2*time.Second))
+	assert.Equal(t, 4, q.Size())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, e := q.TryPop(ctx)
+	assert.ErrorIs(t, e, context.DeadlineExceeded)
+
+	clock.Advance(5 * time.Second)
+	for _, want := range []string{"a", "b", "b2", "c"} {
+		v, e := q.TryPop(context.Background())
+		assert.NoError(t, e)
+		assert.Equal(t, want, v)
+	}
+	assert.Zero(t, q.Size())
+}
+
+func TestDelayQueueWaits(t *testing.T) {
+	clock := newFakeClock()
+	q := queue.NewDelayQueueWithClock[int](clock)
+	assert.NoError(t, q.PushAfter(1, time.Minute))
+
+	popped := popAsync(q)
+	assert.Eventually(t, func() bool { return clock.pending() == 1 }, time.Second, time.Millisecond)
+	assertBlocked(t, popped)
+
+	clock.Advance(59 * time.Second)
+	assertBlocked(t, popped)
+	clock.Advance(time.Second)
+	assert.Equal(t, 1, <-popped)
+}
+
+func TestDelayQueueRearms(t *testing.T) {
+	clock := newFakeClock()
+	q := queue.NewDelayQueueWithClock[string](clock)
+	assert.NoError(t, q.PushAfter("late", time.Hour))
+
+	popped := popAsync(q)
+	assert.Eventually(t, func() bool { return clock.pending() == 1 }, time.Second, time.Millisecond)
+
+	// an earlier element wakes the consumer up, to wait for it instead
+	assert.NoError(t, q.PushAfter("early", time.Second))
+	assertBlocked(t, popped)
+	clock.Advance(time.Second)
+	assert.Equal(t, "early", <-popped)
+	assert.Equal(t, 1, q.Size())
+}
+
+func TestDelayQueueEmpty(t *testing.T) {
+	clock := newFakeClock()
+	q := queue.NewDelayQueueWithClock[int](clock)
+	popped := popAsync(q)
+	assertBlocked(t, popped)
+	// pushed without delay, the element is ready right away
+	assert.NoError(t, q.Push(context.Background(), 7))
+	assert.Equal(t, 7, <-popped)
+
+	assert.NoError(t, q.PushAt(8, clock.Now().Add(-time.Second)))
+	v, e := q.TryPop(context.Background())
+	assert.NoError(t, e)
+	assert.Equal(t, 8, v)
+}
+
+func TestDelayQueueInvalidDelay(t *testing.T) {
+	q := queue.NewDelayQueue[int]()
+	e := q.PushAfter(1, -time.Second)
+	assert.Equal(t, err.NewInvalidTimeIntervalError(-time.Second), e)
+	assert.Zero(t, q.Size())
+}
+
+func TestDelayQueueSystemClock(t *testing.T) {
+	q := queue.NewDelayQueue[int]()
+	start := time.Now()
+	assert.NoError(t, q.PushAfter(2, 20*time.Millisecond))
+	assert.NoError(t, q.PushAfter(1, 10*time.Millisecond))
+	for _, want := range []int{1, 2} {
+		v, e := q.TryPop(context.Background())
+		assert.NoError(t, e)
+		assert.Equal(t, want, v)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.NoError(t, q.PushAfter(3, time.Hour))
+	cancel()
+	_, e := q.TryPop(ctx)
+	assert.ErrorIs(t, e, context.Canceled)
+}
+
+func TestDelayQueueConsumers(t *testing.T) {
+	clock := newFakeClock()
+	q := queue.NewDelayQueueWithClock[int](clock)
+	const n = 100
+	var wg sync.WaitGroup
+	results := make(chan int, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, e := q.TryPop(context.Background())
+			assert.NoError(t, e)
+			results <- v
+		}()
+	}
+	for i := 0; i < n; i++ {
+		assert.NoError(t, q.PushAfter(i, time.Duration(i%10)*time.Second))
+	}
+	// a consumer may arm its timer just after an Advance, keep the time going
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for waiting := true; waiting; {
+		select {
+		case <-done:
+			waiting = false
+		case <-time.After(time.Millisecond):
+			clock.Advance(time.Second)
+		}
+	}
+	close(results)
+	seen := make(map[int]bool)
+	for v := range results {
+		seen[v] = true
+	}
+	assert.Len(t, seen, n)
+}
@@ -0,0 +1,249 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue
+
+import (
+	"github.com/mizumoto-cn/fpkit/internal/err"
+)
+
+// minDequeCap is the smallest buffer of a non-empty Deque.
+const minDequeCap = 8
+
+// Deque is a double-ended queue on a ring buffer, like C++ std::deque.
+// Its buffer doubles when full and halves when a quarter full, always a power of two.
+// As a Queue, it pushes to the back and pops from the front;
+// PushBack and PopBack use it as a stack.
+// The zero value is an empty Deque ready to use. Deque is not thread-safe.
+//
+//	d := queue.NewDeque[int]()
+//	d.PushBack(2)
+//	d.PushFront(1)
+//	v, _ := d.PopBack() // 2
+type Deque[T any] struct {
+	buf  []T
+	head int
+	size int
+}
+
+var _ Queue[int] = (*Deque[int])(nil)
+
+// NewDeque creates a new empty Deque.
+func NewDeque[T any]() *Deque[T] {
+	return &Deque[T]{}
+}
+
+// index returns the position in the buffer of the i-th element.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+// resize moves the elements to a buffer of n slots, a power of two.
+func (d *Deque[T]) resize(n int) {
+	buf := make([]T, n)
+	if d.head+d.size <= len(d.buf) {
+		copy(buf, d.buf[d.head:d.head+d.size])
+	} else {
+		k := copy(buf, d.buf[d.head:])
+		copy(buf[k:], d.buf[:d.size-k])
+	}
+	d.buf = buf
+	d.head = 0
+}
+
+// grow makes room for one more element.
+func (d *Deque[T]) grow() {
+	if d.size == len(d.buf) {
+		d.resize(max(2*len(d.buf), minDequeCap))
+	}
+}
+
+// shrink halves the buffer once it is a quarter full.
+func (d *Deque[T]) shrink() {
+	if len(d.buf) > minDequeCap && d.size <= len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+// PushBack adds an element to the back.
+func (d *Deque[T]) PushBack(t T) {
+	d.grow()
+	d.buf[d.index(d.size)] = t
+	d.size++
+}
+
+// PushFront adds an element to the front.
+func (d *Deque[T]) PushFront(t T) {
+	d.grow()
+	d.head = d.index(len(d.buf) - 1)
+	d.buf[d.head] = t
+	d.size++
+}
+
+// PopBack removes and returns the element at the back.
+func (d *Deque[T]) PopBack() (T, error) {
+	var zero T
+	if d.size == 0 {
+		return zero, err.ErrEmptyQueue
+	}
+	i := d.index(d.size - 1)
+	t := d.buf[i]
+	d.buf[i] = zero
+	d.size--
+	d.shrink()
+	return t, nil
+}
+
+// PopFront removes and returns the element at the front.
+func (d *Deque[T]) PopFront() (T, error) {
+	var zero T
+	if d.size == 0 {
+		return zero, err.ErrEmptyQueue
+	}
+	t := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = d.index(1)
+	d.size--
+	d.shrink()
+	return t, nil
+}
+
+// At returns the i-th element from the front, in O(1).
+func (d *Deque[T]) At(i int) (T, error) {
+	if i < 0 || i >= d.size {
+		var zero T
+		return zero, err.NewIndexOutOfRangeError(i, d.size)
+	}
+	return d.buf[d.index(i)], nil
+}
+
+// Set replaces the i-th element from the front, in O(1).
+func (d *Deque[T]) Set(i int, t T) error {
+	if i < 0 || i >= d.size {
+		return err.NewIndexOutOfRangeError(i, d.size)
+	}
+	d.buf[d.index(i)] = t
+	return nil
+}
+
+// Rotate moves the last n elements to the front, or the first -n elements to the back if n is negative.
+//
+//	// d is [1 2 3 4 5]
+//	d.Rotate(2)  // [4 5 1 2 3]
+//	d.Rotate(-3) // [2 3 4 5 1]
+func (d *Deque[T]) Rotate(n int) {
+	if d.size <= 1 {
+		return
+	}
+	n %= d.size
+	if n < 0 {
+		n += d.size
+	}
+	if n == 0 {
+		return
+	}
+	if d.size == len(d.buf) {
+		// The ring is full, only its start moves.
+		d.head = d.index(d.size - n)
+		return
+	}
+	var zero T
+	if n <= d.size/2 {
+		// back to front
+		for ; n > 0; n-- {
+			last := d.index(d.size - 1)
+			d.head = d.index(len(d.buf) - 1)
+			d.buf[d.head], d.buf[last] = d.buf[last], zero
+		}
+		return
+	}
+	// front to back
+	for n = d.size - n; n > 0; n-- {
+		d.buf[d.index(d.size)], d.buf[d.head] = d.buf[d.head], zero
+		d.head = d.index(1)
+	}
+}
+
+// Push adds an element to the back. It never fails.
+func (d *Deque[T]) Push(t T) error {
+	d.PushBack(t)
+	return nil
+}
+
+// Pop removes and returns the element at the front.
+func (d *Deque[T]) Pop() (T, error) {
+	return d.PopFront()
+}
+
+// Front returns the element at the front.
+func (d *Deque[T]) Front() (T, error) {
+	if d.size == 0 {
+		var zero T
+		return zero, err.ErrEmptyQueue
+	}
+	return d.buf[d.head], nil
+}
+
+// Back returns the element at the back.
+func (d *Deque[T]) Back() (T, error) {
+	if d.size == 0 {
+		var zero T
+		return zero, err.ErrEmptyQueue
+	}
+	return d.buf[d.index(d.size-1)], nil
+}
+
+// Empty returns true if the deque is empty.
+func (d *Deque[T]) Empty() bool {
+	return d.size == 0
+}
+
+// Size returns the number of elements in the deque.
+func (d *Deque[T]) Size() int {
+	return d.size
+}
+
+// Cap returns -1, as the deque grows as needed.
+func (d *Deque[T]) Cap() int {
+	return -1
+}
+
+// Clear removes all elements in the deque, and releases its buffer.
+func (d *Deque[T]) Clear() error {
+	d.buf = nil
+	d.head = 0
+	d.size = 0
+	return nil
+}
+
+// Slice returns the elements from front to back.
+func (d *Deque[T]) Slice() []T {
+	if d.size == 0 {
+		return nil
+	}
+	s := make([]T, d.size)
+	for i := range s {
+		s[i] = d.buf[d.index(i)]
+	}
+	return s
+}
+
+// Swap swaps the contents of two deques.
+func (d *Deque[T]) Swap(other *Deque[T]) {
+	*d, *other = *other, *d
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/internal/err"
+	"github.com/mizumoto-cn/fpkit/queue"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeque(t *testing.T) {
+	var d queue.Deque[int]
+	assert.True(t, d.Empty())
+	assert.Equal(t, -1, d.Cap())
+	_, e := d.PopFront()
+	assert.ErrorIs(t, e, err.ErrEmptyQueue)
+	_, e = d.PopBack()
+	assert.ErrorIs(t, e, err.ErrEmptyQueue)
+	_, e = d.Front()
+	assert.ErrorIs(t, e, err.ErrEmptyQueue)
+	_, e = d.Back()
+	assert.ErrorIs(t, e, err.ErrEmptyQueue)
+	assert.Nil(t, d.Slice())
+
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+	d.PushFront(0)
+	assert.Equal(t, []int{0, 1, 2, 3}, d.Slice())
+	assert.Equal(t, 4, d.Size())
+
+	v, e := d.At(2)
+	assert.NoError(t, e)
+	assert.Equal(t, 2, v)
+	_, e = d.At(4)
+	assert.Equal(t, err.NewIndexOutOfRangeError(4, 4), e)
+	_, e = d.At(-1)
+	assert.Error(t, e)
+	assert.NoError(t, d.Set(2, 20))
+	v, _ = d.At(2)
+	assert.Equal(t, 20, v)
+	assert.Equal(t, err.NewIndexOutOfRangeError(4, 4), d.Set(4, 0))
+	assert.NoError(t, d.Set(2, 2))
+
+	front, _ := d.Front()
+	back, _ := d.Back()
+	assert.Equal(t, 0, front)
+	assert.Equal(t, 3, back)
+
+	v, _ = d.PopFront()
+	assert.Equal(t, 0, v)
+	v, _ = d.PopBack()
+	assert.Equal(t, 3, v)
+	assert.Equal(t, []int{1, 2}, d.Slice())
+
+	assert.NoError(t, d.Clear())
+	assert.True(t, d.Empty())
+}
+
+func TestDequeAsQueueAndStack(t *testing.T) {
+	var q queue.Queue[int] = queue.NewDeque[int]()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, q.Push(i))
+	}
+	for i := 0; i < 3; i++ {
+		v, e := q.Pop()
+		assert.NoError(t, e)
+		assert.Equal(t, i, v)
+	}
+
+	stack := queue.NewDeque[string]()
+	for _, s := range []string{"a", "b", "c"} {
+		stack.PushBack(s)
+	}
+	for _, want := range []string{"c", "b", "a"} {
+		v, e := stack.PopBack()
+		assert.NoError(t, e)
+		assert.Equal(t, want, v)
+	}
+}
+
+func TestDequeRotate(t *testing.T) {
+	d := queue.NewDeque[int]()
+	d.Rotate(3)
+	for i := 1; i <= 5; i++ {
+		d.PushBack(i)
+	}
+	d.Rotate(2)
+	assert.Equal(t, []int{4, 5, 1, 2, 3}, d.Slice())
+	d.Rotate(-3)
+	assert.Equal(t, []int{2, 3, 4, 5, 1}, d.Slice())
+	d.Rotate(5)
+	assert.Equal(t, []int{2, 3, 4, 5, 1}, d.Slice())
+	d.Rotate(-6)
+	assert.Equal(t, []int{3, 4, 5, 1, 2}, d.Slice())
+	d.Rotate(4)
+	assert.Equal(t, []int{4, 5, 1, 2, 3}, d.Slice())
+
+	// a full ring
+	full := queue.NewDeque[int]()
+	for i := 0; i < 8; i++ {
+		full.PushBack(i)
+	}
+	full.Rotate(3)
+	assert.Equal(t, []int{5, 6, 7, 0, 1, 2, 3, 4}, full.Slice())
+	full.PushBack(8)
+	assert.Equal(t, []int{5, 6, 7, 0, 1, 2, 3, 4, 8}, full.Slice())
+}
+
+// rotate is the reference of Deque.Rotate.
+func rotate(s []int, n int) []int {
+	if len(s) == 0 {
+		return s
+	}
+	n = ((n % len(s)) + len(s)) % len(s)
+	return append(append([]int(nil), s[len(s)-n:]...), s[:len(s)-n]...)
+}
+
+func TestDequeModel(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	d := queue.NewDeque[int]()
+	var model []int
+	for i := 0; i < 20000; i++ {
+		// phases of growth and of shrinking
+		grow := (i/2000)%2 == 0
+		switch op := r.Intn(10); {
+		case op < 3 || (grow && op < 6):
+			d.PushBack(i)
+			model = append(model, i)
+		case op < 5 || (grow && op < 8):
+			d.PushFront(i)
+			model = append([]int{i}, model...)
+		case op < 7:
+			v, e := d.PopFront()
+			if len(model) == 0 {
+				assert.Error(t, e)
+				continue
+			}
+			assert.Equal(t, model[0], v)
+			model = model[1:]
+		case op < 9:
+			v, e := d.PopBack()
+			if len(model) == 0 {
+				assert.Error(t, e)
+				continue
+			}
+			assert.Equal(t, model[len(model)-1], v)
+			model = model[:len(model)-1]
+		default:
+			n := r.Intn(21) - 10
+			d.Rotate(n)
+			model = rotate(model, n)
+		}
+		if !assert.Equal(t, len(model), d.Size()) {
+			return
+		}
+		if len(model) > 0 {
+			j := r.Intn(len(model))
+			v, e := d.At(j)
+			assert.NoError(t, e)
+			assert.Equal(t, model[j], v)
+		}
+	}
+	if len(model) == 0 {
+		assert.Nil(t, d.Slice())
+	} else {
+		assert.Equal(t, model, d.Slice())
+	}
+}
+
+func TestDequeSwap(t *testing.T) {
+	a, b := queue.NewDeque[int](), queue.NewDeque[int]()
+	a.PushBack(1)
+	b.PushBack(2)
+	b.PushBack(3)
+	a.Swap(b)
+	assert.Equal(t, []int{2, 3}, a.Slice())
+	assert.Equal(t, []int{1}, b.Slice())
+}
+
+func BenchmarkDeque(b *testing.B) {
+	d := queue.NewDeque[int]()
+	for i := 0; i < b.N; i++ {
+		d.PushBack(i)
+		if i%3 == 0 {
+			_, _ = d.PopFront()
+		}
+	}
+}
@@ -27,6 +27,11 @@ import (
 
 // LinkedQueue is a queue implemented using a linked list.
 // LinkedQueue is thread-safe.
+//
+// It is the lock-free queue of Michael and Scott: head points to a sentinel node,
+// whose successor holds the first element, and tail points to the last node or,
+// briefly, to its predecessor. Every goroutine finding tail lagging behind
+// advances it before going on, so none of them waits for another.
 type LinkedQueue[T any] struct {
 	// head *node[T]
 	head unsafe.Pointer
@@ -37,7 +42,8 @@ type LinkedQueue[T any] struct {
 
 // node is a node in the linked list.
 type node[T any] struct {
-	value T
+	// value *T, nil once the node has been dequeued and became the sentinel
+	value unsafe.Pointer
 	next  unsafe.Pointer
 }
 
@@ -61,20 +67,22 @@ func (q *LinkedQueue[T]) updateSize(delta int32) {
 
 // Push adds an element to the end of the queue.
 func (q *LinkedQueue[T]) Push(t T) error {
-	n := &node[T]{value: t}
-	ptr := unsafe.Pointer(n)
+	ptr := unsafe.Pointer(&node[T]{value: unsafe.Pointer(&t)})
 	for {
 		tailPtr := atomic.LoadPointer(&q.tail)
 		tail := (*node[T])(tailPtr)
 		next := atomic.LoadPointer(&tail.next)
+		if tailPtr != atomic.LoadPointer(&q.tail) {
+			continue
+		}
 		if next != nil {
-			// Someone has edited the tail
-			// Simply retry to wait for the q.tail to be updated
+			// Someone has linked a node but not advanced the tail yet, help it
+			cas(&q.tail, tailPtr, next)
 			continue
 		}
-		if cas(&tail.next, next, ptr) {
+		if cas(&tail.next, nil, ptr) {
+			// Failing is fine, someone has helped already
 			cas(&q.tail, tailPtr, ptr)
-			// atomic.AddInt32(&q.size, 1)
 			q.updateSize(1)
 			return nil
 		}
@@ -85,33 +93,44 @@ func (q *LinkedQueue[T]) Push(t T) error {
 func (q *LinkedQueue[T]) Pop() (T, error) {
 	for {
 		hp := atomic.LoadPointer(&q.head)
-		head := (*node[T])(hp)
 		tp := atomic.LoadPointer(&q.tail)
-		tail := (*node[T])(tp)
-		if head == tail {
-			// The queue is empty
-			// Or at least we think it is empty
-			var zero T
-			return zero, err.ErrEmptyQueue
+		np := atomic.LoadPointer(&(*node[T])(hp).next)
+		if hp != atomic.LoadPointer(&q.head) {
+			// head, tail and next are not a consistent snapshot
+			continue
+		}
+		if hp == tp {
+			if np == nil {
+				var zero T
+				return zero, err.ErrEmptyQueue
+			}
+			// The tail is lagging behind, help it before moving the head past it
+			cas(&q.tail, tp, np)
+			continue
 		}
-		np := atomic.LoadPointer(&head.next)
+		next := (*node[T])(np)
+		// Read the value before the swap, the winner clears it right after
+		vp := atomic.LoadPointer(&next.value)
 		if cas(&q.head, hp, np) {
-			next := (*node[T])(np)
+			// next is the sentinel now, let its value go
+			atomic.StorePointer(&next.value, nil)
 			q.updateSize(-1)
-			return next.value, nil
+			return *(*T)(vp), nil
 		}
 	}
 }
 
 // Empty returns true if the queue is empty.
 func (q *LinkedQueue[T]) Empty() bool {
-	size := atomic.LoadInt32(&q.size)
-	return size == 0
+	hp := atomic.LoadPointer(&q.head)
+	return atomic.LoadPointer(&(*node[T])(hp).next) == nil
 }
 
 // Size returns the number of elements in the queue.
+// The count is updated after each Push and Pop, so it may be briefly off
+// while they are running.
 func (q *LinkedQueue[T]) Size() int {
-	return (int)(atomic.LoadInt32(&q.size))
+	return max(int(atomic.LoadInt32(&q.size)), 0)
 }
 
 // Cap returns the capacity of the queue.
@@ -123,26 +142,39 @@ func (q *LinkedQueue[T]) Cap() int {
 // Back returns the last element in the queue.
 // Deprecated: Not necessarily real-time as the queue may be updated
 func (q *LinkedQueue[T]) Back() (T, error) {
-	if q.Empty() {
-		var zero T
-		return zero, err.ErrEmptyQueue
+	for {
+		tp := atomic.LoadPointer(&q.tail)
+		tail := (*node[T])(tp)
+		next := atomic.LoadPointer(&tail.next)
+		if next != nil {
+			cas(&q.tail, tp, next)
+			continue
+		}
+		// The value of the sentinel is nil: the queue is empty
+		vp := atomic.LoadPointer(&tail.value)
+		if vp == nil {
+			var zero T
+			return zero, err.ErrEmptyQueue
+		}
+		return *(*T)(vp), nil
 	}
-	tp := atomic.LoadPointer(&q.tail)
-	tail := (*node[T])(tp)
-	return tail.value, nil
 }
 
 // Front returns the first element in the queue.
 func (q *LinkedQueue[T]) Front() (T, error) {
-	if q.Empty() {
-		var zero T
-		return zero, err.ErrEmptyQueue
+	for {
+		hp := atomic.LoadPointer(&q.head)
+		np := atomic.LoadPointer(&(*node[T])(hp).next)
+		if np == nil {
+			var zero T
+			return zero, err.ErrEmptyQueue
+		}
+		vp := atomic.LoadPointer(&(*node[T])(np).value)
+		if vp != nil {
+			return *(*T)(vp), nil
+		}
+		// The element has just been popped, look again
 	}
-	hp := atomic.LoadPointer(&q.head)
-	head := (*node[T])(hp)
-	np := atomic.LoadPointer(&head.next)
-	next := (*node[T])(np)
-	return next.value, nil
 }
 
 // Clear removes all elements from the queue.
@@ -153,30 +185,30 @@ func (q *LinkedQueue[T]) Front() (T, error) {
 //	TODO: may need updates on this method as it surely is error-prone
 //	and may cause unexpected behaviors.
 func (q *LinkedQueue[T]) Clear() error {
-	for !q.Empty() {
-		_, err := q.Pop()
-		if err != nil {
-			return err
+	for {
+		if _, e := q.Pop(); e != nil {
+			// Empty, possibly thanks to others popping concurrently
+			return nil
 		}
 	}
-	return nil
 }
 
 // Slice returns a slice of the elements in the queue.
 // Deprecated: Not necessarily real-time as the queue may be updated
 func (q *LinkedQueue[T]) Slice() []T {
-	if q.Empty() {
+	hp := atomic.LoadPointer(&q.head)
+	np := atomic.LoadPointer(&(*node[T])(hp).next)
+	if np == nil {
 		return nil
 	}
-	hp := atomic.LoadPointer(&q.head)
-	head := (*node[T])(hp)
-	np := atomic.LoadPointer(&head.next)
-	next := (*node[T])(np)
 	slice := make([]T, 0, q.Size())
-	for next != nil {
-		slice = append(slice, next.value)
+	for np != nil {
+		next := (*node[T])(np)
+		// Skip the nodes popped since we started
+		if vp := atomic.LoadPointer(&next.value); vp != nil {
+			slice = append(slice, *(*T)(vp))
+		}
 		np = atomic.LoadPointer(&next.next)
-		next = (*node[T])(np)
 	}
 	return slice
 }
@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// LinkedBlockingQueue is a thread-safe FIFO queue on a linked list,
+// bounded or not.
+// Push and TryPop take separate locks, so a producer and a consumer never
+// wait for each other unless the queue is full or empty.
+type LinkedBlockingQueue[T any] struct {
+	// head is a sentinel, head.next holds the first element
+	head     *lnode[T]
+	tail     *lnode[T]
+	cap      int
+	size     atomic.Int64
+	putLock  sync.Mutex
+	takeLock sync.Mutex
+	notEmpty counter
+	// notFull is nil for unbounded queues
+	notFull *semaphore.Weighted
+	closer  closer
+}
+
+// lnode is a node of a LinkedBlockingQueue.
+type lnode[T any] struct {
+	value T
+	next  *lnode[T]
+}
+
+var _ BlockingQueue[int] = (*LinkedBlockingQueue[int])(nil)
+
+// NewLinkedBlockingQueue creates a new LinkedBlockingQueue holding at most cap elements.
+// A capacity of zero or less means unbounded.
+func NewLinkedBlockingQueue[T any](cap int) *LinkedBlockingQueue[T] {
+	n := &lnode[T]{}
+	q := &LinkedBlockingQueue[T]{head: n, tail: n, cap: -1, closer: newCloser()}
+	if cap > 0 {
+		q.cap = cap
+		q.notFull = semaphore.NewWeighted(int64(cap))
+	}
+	return q
+}
+
+// Push adds an element to the end of the queue, blocking while it is full.
+// An unbounded queue never blocks.
+// When cancelled or timeout, return context.Canceled or context.DeadlineExceeded.
+// Shall always use errors.Is(err, context.Canceled) or errors.Is(err, context.DeadlineExceeded) to check the error.
+// Once the queue is closed, return ErrQueueClosed.
+func (q *LinkedBlockingQueue[T]) Push(ctx context.Context, t T) error {
+	if q.closer.isClosed() {
+		return ErrQueueClosed
+	}
+	if q.notFull != nil {
+		if err := q.closer.acquire(ctx, q.notFull); err != nil {
+			return err
+		}
+	}
+
+	n := &lnode[T]{value: t}
+	q.putLock.Lock()
+	defer q.putLock.Unlock()
+	if q.closer.isClosed() {
+		q.release()
+		return ErrQueueClosed
+	}
+	q.tail.next = n
+	q.tail = n
+	q.size.Add(1)
+	// Count the element under the lock, see closer.
+	q.notEmpty.Release(1)
+	return nil
+}
+
+// TryPop removes and returns the first element of the queue, blocking until there is one.
+// When cancelled or timeout, return context.Canceled or context.DeadlineExceeded.
+// Shall always use errors.Is(err, context.Canceled) or errors.Is(err, context.DeadlineExceeded) to check the error.
+// Once the queue is closed and drained, return ErrQueueClosed.
+func (q *LinkedBlockingQueue[T]) TryPop(ctx context.Context) (T, error) {
+	var zero T
+	if err := q.closer.acquireItem(ctx, &q.notEmpty); err != nil {
+		return zero, err
+	}
+
+	// The acquired slot guarantees head.next is linked, and Push is done with it,
+	// unless CloseNow has discarded the elements.
+	q.takeLock.Lock()
+	defer q.takeLock.Unlock()
+	first := q.head.next
+	if first == nil {
+		return zero, ErrQueueClosed
+	}
+	t := first.value
+	// first becomes the sentinel, let its value and the old head go
+	first.value = zero
+	q.head.next = nil
+	q.head = first
+	q.size.Add(-1)
+	q.release()
+	return t, nil
+}
+
+// release frees a slot of a bounded queue.
+func (q *LinkedBlockingQueue[T]) release() {
+	if q.notFull != nil {
+		q.notFull.Release(1)
+	}
+}
+
+// Close closes the queue: the pending and later pushes fail with ErrQueueClosed,
+// the pops drain the remaining elements, then fail with ErrQueueClosed.
+func (q *LinkedBlockingQueue[T]) Close() {
+	q.putLock.Lock()
+	defer q.putLock.Unlock()
+	q.closer.close()
+}
+
+// CloseNow closes the queue and discards its elements.
+func (q *LinkedBlockingQueue[T]) CloseNow() {
+	q.putLock.Lock()
+	defer q.putLock.Unlock()
+	q.takeLock.Lock()
+	defer q.takeLock.Unlock()
+	q.closer.close()
+	q.head.next = nil
+	q.tail = q.head
+	q.size.Store(0)
+}
+
+// IsClosed reports whether the queue has been closed.
+func (q *LinkedBlockingQueue[T]) IsClosed() bool {
+	return q.closer.isClosed()
+}
+
+// Size returns the number of elements in the queue, at the time of calling.
+func (q *LinkedBlockingQueue[T]) Size() int {
+	return int(q.size.Load())
+}
+
+// Cap returns the capacity of the queue.
+// For unbounded queues, Cap returns -1.
+func (q *LinkedBlockingQueue[T]) Cap() int {
+	return q.cap
+}
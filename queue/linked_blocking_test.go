@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/queue"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkedBlockingQueueUnbounded(t *testing.T) {
+	q := queue.NewLinkedBlockingQueue[int](0)
+	assert.Zero(t, q.Size())
+	assert.Equal(t, -1, q.Cap())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	out, err := q.TryPop(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Zero(t, out)
+
+	for i := 0; i < 1000; i++ {
+		assert.NoError(t, q.Push(context.Background(), i))
+	}
+	assert.Equal(t, 1000, q.Size())
+	for i := 0; i < 1000; i++ {
+		v, err := q.TryPop(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, i, v)
+	}
+	assert.Zero(t, q.Size())
+}
+
+func TestLinkedBlockingQueueBounded(t *testing.T) {
+	q := queue.NewLinkedBlockingQueue[int](2)
+	assert.Equal(t, 2, q.Cap())
+	assert.NoError(t, q.Push(context.Background(), 1))
+	assert.NoError(t, q.Push(context.Background(), 2))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, q.Push(ctx, 3), context.DeadlineExceeded)
+	assert.Equal(t, 2, q.Size())
+
+	// a blocked Push goes through once an element is taken
+	pushed := make(chan error)
+	go func() {
+		pushed <- q.Push(context.Background(), 3)
+	}()
+	v, err := q.TryPop(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	assert.NoError(t, <-pushed)
+
+	for _, want := range []int{2, 3} {
+		v, err = q.TryPop(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, want, v)
+	}
+}
+
+func TestLinkedBlockingQueueTryPopWaits(t *testing.T) {
+	q := queue.NewLinkedBlockingQueue[string](0)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, q.Push(context.Background(), "late"))
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	v, err := q.TryPop(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "late", v)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = q.TryPop(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLinkedBlockingQueueRace(t *testing.T) {
+	for _, cap := range []int{0, 8} {
+		q := queue.NewLinkedBlockingQueue[int](cap)
+		const producers, perProducer = 8, 1000
+		var wg sync.WaitGroup
+		for p := 0; p < producers; p++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < perProducer; i++ {
+					assert.NoError(t, q.Push(context.Background(), p*perProducer+i))
+				}
+			}()
+		}
+		results := make(chan int, producers*perProducer)
+		for c := 0; c < 8; c++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < perProducer; i++ {
+					v, err := q.TryPop(context.Background())
+					assert.NoError(t, err)
+					results <- v
+				}
+			}()
+		}
+		wg.Wait()
+		close(results)
+
+		seen := make(map[int]bool)
+		for v := range results {
+			assert.False(t, seen[v])
+			seen[v] = true
+		}
+		assert.Len(t, seen, producers*perProducer)
+		assert.Zero(t, q.Size())
+	}
+}
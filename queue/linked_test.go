@@ -20,9 +20,11 @@ package queue_test
 
 import (
 	"math/rand"
+	"runtime"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/mizumoto-cn/fpkit/functional"
 	"github.com/mizumoto-cn/fpkit/internal/err"
@@ -450,3 +452,130 @@ func TestClearRace(t *testing.T) {
 		close(errs)
 	}()
 }
+
+func TestLinkedQueueStress(t *testing.T) {
+	t.Parallel()
+	const producers, consumers, perProducer = 8, 8, 5000
+	q := queue.NewLinkedQueue[int]()
+	var popped atomic.Int32
+	seen := make([][]int, consumers)
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				assert.NoError(t, q.Push(p*perProducer+i))
+			}
+		}()
+	}
+	for c := 0; c < consumers; c++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for popped.Load() < producers*perProducer {
+				if v, e := q.Pop(); e == nil {
+					popped.Add(1)
+					seen[c] = append(seen[c], v)
+				} else {
+					assert.ErrorIs(t, e, err.ErrEmptyQueue)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	counts := make([]int, producers*perProducer)
+	for _, s := range seen {
+		// each consumer sees the items of a producer in the order they were pushed
+		last := make([]int, producers)
+		for i := range last {
+			last[i] = -1
+		}
+		for _, v := range s {
+			counts[v]++
+			assert.Greater(t, v, last[v/perProducer])
+			last[v/perProducer] = v
+		}
+	}
+	for v, n := range counts {
+		assert.Equal(t, 1, n, "value %d", v)
+	}
+	assert.True(t, q.Empty())
+	assert.Equal(t, 0, q.Size())
+	_, e := q.Front()
+	assert.ErrorIs(t, e, err.ErrEmptyQueue)
+	_, e = q.Back()
+	assert.ErrorIs(t, e, err.ErrEmptyQueue)
+}
+
+func TestLinkedQueueConcurrentReads(t *testing.T) {
+	t.Parallel()
+	q := queue.NewLinkedQueue[int]()
+	var done atomic.Bool
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 2000; j++ {
+				assert.NoError(t, q.Push(j))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 2000; {
+				if _, e := q.Pop(); e == nil {
+					j++
+				}
+			}
+		}()
+	}
+	readers := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for !done.Load() {
+				if v, e := q.Front(); e == nil {
+					assert.True(t, v >= 0 && v < 2000)
+				}
+				if v, e := q.Back(); e == nil {
+					assert.True(t, v >= 0 && v < 2000)
+				}
+				for _, v := range q.Slice() {
+					assert.True(t, v >= 0 && v < 2000)
+				}
+				assert.GreaterOrEqual(t, q.Size(), 0)
+				q.Empty()
+			}
+		}()
+	}
+	wg.Wait()
+	done.Store(true)
+	readers.Wait()
+	assert.True(t, q.Empty())
+}
+
+func TestLinkedQueueReleasesValues(t *testing.T) {
+	t.Parallel()
+	const n = 10
+	var finalized atomic.Int32
+	q := queue.NewLinkedQueue[*[64]byte]()
+	for i := 0; i < n; i++ {
+		v := new([64]byte)
+		runtime.SetFinalizer(v, func(*[64]byte) { finalized.Add(1) })
+		assert.NoError(t, q.Push(v))
+	}
+	for i := 0; i < n; i++ {
+		_, e := q.Pop()
+		assert.NoError(t, e)
+	}
+	// the last popped node stays as the sentinel, its value must not
+	assert.Eventually(t, func() bool {
+		runtime.GC()
+		return finalized.Load() == n
+	}, 5*time.Second, 10*time.Millisecond)
+	runtime.KeepAlive(q)
+}
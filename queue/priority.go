@@ -24,6 +24,8 @@ import (
 )
 
 // PriorityQueue is a priority queue with a fixed capacity.
+// It keeps its elements sorted in a ring buffer: Push inserts in O(n), Pop and Front take the first in O(1),
+// and Back is the last one by the comparator.
 //
 //	pq := queue.NewPriorityQueue[int](functional.Less[int], 3)
 type PriorityQueue[T any] struct {
@@ -68,7 +70,7 @@ func (pq *PriorityQueue[T]) Push(v T) error {
 	return nil
 }
 
-// up moves the element at index i up the heap to its correct position.
+// up moves the element at index i towards the head to its sorted position.
 func (pq *PriorityQueue[T]) up(i int) {
 	for {
 		parent := (i - 1 + pq.cap) % pq.cap
@@ -80,26 +82,6 @@ func (pq *PriorityQueue[T]) up(i int) {
 	}
 }
 
-// down moves the element at index i down the heap to its correct position.
-func (pq *PriorityQueue[T]) down(i int) {
-	for {
-		left := (2*i + 1) % pq.cap
-		if left == pq.tail || left == pq.head {
-			break
-		}
-		j := left
-		right := (left + 1) % pq.cap
-		if right != pq.tail && right != pq.head && pq.cmp(pq.data[right], pq.data[left]) {
-			j = right
-		}
-		if pq.cmp(pq.data[i], pq.data[j]) {
-			break
-		}
-		pq.data[i], pq.data[j] = pq.data[j], pq.data[i]
-		i = j
-	}
-}
-
 // Pop removes and returns the first element in the priority queue.
 func (pq *PriorityQueue[T]) Pop() (T, error) {
 	if pq.count == 0 {
@@ -107,9 +89,11 @@ func (pq *PriorityQueue[T]) Pop() (T, error) {
 		return zero, err.NewIndexOutOfRangeError(0, pq.count)
 	}
 	v := pq.data[pq.head]
+	// Let the popped element go, the rest stays sorted
+	var zero T
+	pq.data[pq.head] = zero
 	pq.head = (pq.head + 1) % pq.cap
 	pq.count--
-	pq.down(pq.head)
 	return v, nil
 }
 
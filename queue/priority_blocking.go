@@ -0,0 +1,185 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// PriorityBlockingQueue is a thread-safe priority queue, bounded or not.
+// TryPop returns the element coming first by the comparator, blocking while the queue is empty.
+//
+//	pq := queue.NewPriorityBlockingQueue[int](functional.Less[int], 0)
+type PriorityBlockingQueue[T any] struct {
+	cmp  functional.ComparatorAny[T]
+	heap []T
+	cap  int
+	lock sync.Mutex
+	// notEmpty has a permit per element
+	notEmpty counter
+	// notFull is nil for unbounded queues
+	notFull *semaphore.Weighted
+	closer  closer
+}
+
+var _ BlockingQueue[int] = (*PriorityBlockingQueue[int])(nil)
+
+// NewPriorityBlockingQueue creates a new PriorityBlockingQueue ordered by cmp, holding at most cap elements.
+// A capacity of zero or less means unbounded.
+func NewPriorityBlockingQueue[T any](cmp functional.ComparatorAny[T], cap int) *PriorityBlockingQueue[T] {
+	q := &PriorityBlockingQueue[T]{cmp: cmp, cap: -1, closer: newCloser()}
+	if cap > 0 {
+		q.cap = cap
+		q.heap = make([]T, 0, cap)
+		q.notFull = semaphore.NewWeighted(int64(cap))
+	}
+	return q
+}
+
+// Push adds an element to the queue, blocking while it is full.
+// An unbounded queue never blocks.
+// When cancelled or timeout, return context.Canceled or context.DeadlineExceeded.
+// Shall always use errors.Is(err, context.Canceled) or errors.Is(err, context.DeadlineExceeded) to check the error.
+// Once the queue is closed, return ErrQueueClosed.
+func (q *PriorityBlockingQueue[T]) Push(ctx context.Context, t T) error {
+	if q.closer.isClosed() {
+		return ErrQueueClosed
+	}
+	if q.notFull != nil {
+		if err := q.closer.acquire(ctx, q.notFull); err != nil {
+			return err
+		}
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.closer.isClosed() {
+		q.release()
+		return ErrQueueClosed
+	}
+	q.heap = append(q.heap, t)
+	q.up(len(q.heap) - 1)
+	// Count the element under the lock, see closer.
+	q.notEmpty.Release(1)
+	return nil
+}
+
+// TryPop removes and returns the first element of the queue, blocking until there is one.
+// When cancelled or timeout, return context.Canceled or context.DeadlineExceeded.
+// Shall always use errors.Is(err, context.Canceled) or errors.Is(err, context.DeadlineExceeded) to check the error.
+// Once the queue is closed and drained, return ErrQueueClosed.
+func (q *PriorityBlockingQueue[T]) TryPop(ctx context.Context) (T, error) {
+	var zero T
+	if err := q.closer.acquireItem(ctx, &q.notEmpty); err != nil {
+		return zero, err
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	// The elements have been discarded by CloseNow.
+	if len(q.heap) == 0 {
+		return zero, ErrQueueClosed
+	}
+	t := q.heap[0]
+	last := len(q.heap) - 1
+	q.heap[0] = q.heap[last]
+	q.heap[last] = zero
+	q.heap = q.heap[:last]
+	q.down(0)
+	q.release()
+	return t, nil
+}
+
+// release frees a slot of a bounded queue.
+func (q *PriorityBlockingQueue[T]) release() {
+	if q.notFull != nil {
+		q.notFull.Release(1)
+	}
+}
+
+// Close closes the queue: the pending and later pushes fail with ErrQueueClosed,
+// the pops drain the remaining elements, then fail with ErrQueueClosed.
+func (q *PriorityBlockingQueue[T]) Close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.closer.close()
+}
+
+// CloseNow closes the queue and discards its elements.
+func (q *PriorityBlockingQueue[T]) CloseNow() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.closer.close()
+	clear(q.heap)
+	q.heap = q.heap[:0]
+}
+
+// IsClosed reports whether the queue has been closed.
+func (q *PriorityBlockingQueue[T]) IsClosed() bool {
+	return q.closer.isClosed()
+}
+
+// up moves the element at index i up the heap to its correct position.
+func (q *PriorityBlockingQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.cmp(q.heap[i], q.heap[parent]) {
+			break
+		}
+		q.heap[parent], q.heap[i] = q.heap[i], q.heap[parent]
+		i = parent
+	}
+}
+
+// down moves the element at index i down the heap to its correct position.
+func (q *PriorityBlockingQueue[T]) down(i int) {
+	n := len(q.heap)
+	for {
+		j := 2*i + 1
+		if j >= n {
+			break
+		}
+		if right := j + 1; right < n && q.cmp(q.heap[right], q.heap[j]) {
+			j = right
+		}
+		if !q.cmp(q.heap[j], q.heap[i]) {
+			break
+		}
+		q.heap[i], q.heap[j] = q.heap[j], q.heap[i]
+		i = j
+	}
+}
+
+// Size returns the number of elements in the queue, at the time of calling.
+func (q *PriorityBlockingQueue[T]) Size() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return len(q.heap)
+}
+
+// Cap returns the capacity of the queue.
+// For unbounded queues, Cap returns -1.
+func (q *PriorityBlockingQueue[T]) Cap() int {
+	return q.cap
+}
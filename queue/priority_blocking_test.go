@@ -0,0 +1,181 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue_test
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/queue"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityBlockingQueueOrder(t *testing.T) {
+	q := queue.NewPriorityBlockingQueue[int](functional.Less[int], 0)
+	assert.Equal(t, -1, q.Cap())
+	in := rand.Perm(500)
+	for _, v := range in {
+		assert.NoError(t, q.Push(context.Background(), v))
+	}
+	assert.Equal(t, 500, q.Size())
+	for i := 0; i < 500; i++ {
+		v, err := q.TryPop(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, i, v)
+	}
+	assert.Zero(t, q.Size())
+
+	greatest := queue.NewPriorityBlockingQueue[string](functional.Greater[string], 0)
+	for _, s := range []string{"b", "c", "a"} {
+		assert.NoError(t, greatest.Push(context.Background(), s))
+	}
+	s, err := greatest.TryPop(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "c", s)
+}
+
+func TestPriorityBlockingQueueBlocks(t *testing.T) {
+	q := queue.NewPriorityBlockingQueue[int](functional.Less[int], 2)
+	assert.Equal(t, 2, q.Cap())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := q.TryPop(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	assert.NoError(t, q.Push(context.Background(), 5))
+	assert.NoError(t, q.Push(context.Background(), 3))
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, q.Push(ctx, 1), context.DeadlineExceeded)
+
+	pushed := make(chan error)
+	go func() {
+		pushed <- q.Push(context.Background(), 1)
+	}()
+	v, err := q.TryPop(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+	assert.NoError(t, <-pushed)
+	v, err = q.TryPop(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	// a waiting TryPop gets the element pushed later
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, q.Push(context.Background(), 9))
+	}()
+	v, err = q.TryPop(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 5, v)
+	v, err = q.TryPop(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 9, v)
+}
+
+func TestPriorityBlockingQueueRace(t *testing.T) {
+	q := queue.NewPriorityBlockingQueue[int](functional.Less[int], 16)
+	const producers, perProducer = 8, 500
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				assert.NoError(t, q.Push(context.Background(), p*perProducer+i))
+			}
+		}()
+	}
+	results := make(chan int, producers*perProducer)
+	for c := 0; c < producers; c++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				v, err := q.TryPop(context.Background())
+				assert.NoError(t, err)
+				results <- v
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	var got []int
+	for v := range results {
+		got = append(got, v)
+	}
+	sort.Ints(got)
+	for i, v := range got {
+		assert.Equal(t, i, v)
+	}
+	assert.Zero(t, q.Size())
+}
+
+// mutexPriorityQueue is the baseline of the benchmarks: a PriorityQueue behind a mutex.
+type mutexPriorityQueue struct {
+	mu sync.Mutex
+	pq *queue.PriorityQueue[int]
+}
+
+func (q *mutexPriorityQueue) Push(v int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Push(v)
+}
+
+func (q *mutexPriorityQueue) Pop() (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Pop()
+}
+
+const benchmarkPriorityCap = 1 << 16
+
+func BenchmarkPriorityBlockingQueue(b *testing.B) {
+	q := queue.NewPriorityBlockingQueue[int](functional.Less[int], benchmarkPriorityCap)
+	ctx := context.Background()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			_ = q.Push(ctx, r.Int())
+			_, _ = q.TryPop(ctx)
+		}
+	})
+}
+
+func BenchmarkMutexPriorityQueue(b *testing.B) {
+	pq, err := queue.NewPriorityQueue[int](functional.Less[int], benchmarkPriorityCap)
+	assert.NoError(b, err)
+	q := &mutexPriorityQueue{pq: pq}
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			_ = q.Push(r.Int())
+			_, _ = q.Pop()
+		}
+	})
+}
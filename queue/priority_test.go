@@ -19,6 +19,8 @@
 package queue_test
 
 import (
+	"math/rand"
+	"sort"
 	"testing"
 
 	"github.com/mizumoto-cn/fpkit/functional"
@@ -129,4 +131,29 @@ func TestPriorityQueue(t *testing.T) {
 	assert.Equal(t, obj{Name: "Bob", Age: 30}, v)
 }
 
-// TODO: Add more test cases to cover all scenarios of `down` function in priority.go
+func TestPriorityQueueInterleaved(t *testing.T) {
+	pq, err := queue.NewPriorityQueue[int](functional.Less[int], 8)
+	assert.NoError(t, err)
+	r := rand.New(rand.NewSource(1))
+	var model []int
+	for i := 0; i < 2000; i++ {
+		if len(model) < pq.Cap() && (len(model) == 0 || r.Intn(2) == 0) {
+			v := r.Intn(100)
+			assert.NoError(t, pq.Push(v))
+			model = append(model, v)
+			sort.Ints(model)
+		} else {
+			v, err := pq.Pop()
+			assert.NoError(t, err)
+			assert.Equal(t, model[0], v)
+			model = model[1:]
+		}
+		assert.Equal(t, len(model), pq.Size())
+		if len(model) > 0 {
+			front, _ := pq.Front()
+			back, _ := pq.Back()
+			assert.Equal(t, model[0], front)
+			assert.Equal(t, model[len(model)-1], back)
+		}
+	}
+}
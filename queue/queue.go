@@ -46,7 +46,8 @@ type Queue[T any] interface {
 	Clear() error
 }
 
-// TODO: reference of C++ 11 std::queue
+// Reference of C++ 11 std::queue, which the std package implements as std.Queue
+// over a std.FIFOContainer (container_type), with Emplace and Swap.
 // member type		definition									notes
 // value_type		The first template parameter (T)			Type of the elements
 // container_type	The second template parameter (Container)	Type of the underlying container
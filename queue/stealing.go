@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue
+
+import "sync/atomic"
+
+// minStealingCap is the initial buffer of a WorkStealingDeque.
+const minStealingCap = 32
+
+// WorkStealingDeque is the lock-free deque of Chase and Lev, for the local queue of a worker.
+// Its owner pushes and pops at the bottom, in LIFO order, while the other goroutines
+// steal from the top, in FIFO order. Push and Pop must only be called by the owner.
+// The buffer doubles when full.
+//
+//	d := queue.NewWorkStealingDeque[func()]()
+//	d.Push(task)           // owner
+//	task, ok := d.Pop()    // owner
+//	task, ok = d.Steal()   // any thief
+type WorkStealingDeque[T any] struct {
+	// top is the next index to steal, bottom the next index to push.
+	top    atomic.Int64
+	bottom atomic.Int64
+	ring   atomic.Pointer[ring[T]]
+}
+
+// ring is the circular buffer of a WorkStealingDeque, indexed modulo its power of two length.
+// The slots are atomic, as a thief may read one the owner is overwriting: its steal then fails.
+type ring[T any] struct {
+	slots []atomic.Pointer[T]
+	mask  int64
+}
+
+func newRing[T any](n int64) *ring[T] {
+	return &ring[T]{slots: make([]atomic.Pointer[T], n), mask: n - 1}
+}
+
+func (r *ring[T]) get(i int64) *T {
+	return r.slots[i&r.mask].Load()
+}
+
+func (r *ring[T]) put(i int64, p *T) {
+	r.slots[i&r.mask].Store(p)
+}
+
+// clear empties the slot if it still holds p, and not an element pushed since.
+func (r *ring[T]) clear(i int64, p *T) {
+	r.slots[i&r.mask].CompareAndSwap(p, nil)
+}
+
+// grow returns a ring twice as big with the elements from top to bottom.
+func (r *ring[T]) grow(top, bottom int64) *ring[T] {
+	g := newRing[T](2 * int64(len(r.slots)))
+	for i := top; i < bottom; i++ {
+		g.put(i, r.get(i))
+	}
+	return g
+}
+
+// NewWorkStealingDeque creates a new empty WorkStealingDeque.
+func NewWorkStealingDeque[T any]() *WorkStealingDeque[T] {
+	d := &WorkStealingDeque[T]{}
+	d.ring.Store(newRing[T](minStealingCap))
+	return d
+}
+
+// Push adds an element at the bottom. Only the owner may call it.
+func (d *WorkStealingDeque[T]) Push(t T) {
+	b := d.bottom.Load()
+	top := d.top.Load()
+	r := d.ring.Load()
+	if b-top >= int64(len(r.slots)) {
+		// The thieves still reading the old ring fail their steal, or get the same element.
+		r = r.grow(top, b)
+		d.ring.Store(r)
+	}
+	r.put(b, &t)
+	d.bottom.Store(b + 1)
+}
+
+// Pop removes and returns the element at the bottom, the last pushed.
+// It returns false if the deque is empty. Only the owner may call it.
+func (d *WorkStealingDeque[T]) Pop() (T, bool) {
+	var zero T
+	// Reserve the bottom element first, then see whether the thieves got to it.
+	b := d.bottom.Load() - 1
+	r := d.ring.Load()
+	d.bottom.Store(b)
+	top := d.top.Load()
+	if top > b {
+		// Empty
+		d.bottom.Store(b + 1)
+		return zero, false
+	}
+	p := r.get(b)
+	if top == b {
+		// The last element, race the thieves for it.
+		won := d.top.CompareAndSwap(top, top+1)
+		d.bottom.Store(b + 1)
+		if !won {
+			return zero, false
+		}
+	}
+	// Let the element go, no thief can steal this index anymore.
+	r.put(b, nil)
+	return *p, true
+}
+
+// Steal removes and returns the element at the top, the first pushed.
+// It returns false if the deque is empty. Any goroutine may call it.
+// A stolen element is let go like a popped one, unless a concurrent growth has copied it
+// to a ring not published yet, which keeps it until the owner pushes over it.
+func (d *WorkStealingDeque[T]) Steal() (T, bool) {
+	for {
+		top := d.top.Load()
+		b := d.bottom.Load()
+		if top >= b {
+			var zero T
+			return zero, false
+		}
+		r := d.ring.Load()
+		p := r.get(top)
+		if d.top.CompareAndSwap(top, top+1) {
+			// The owner may already push over the slot, so only clear it if it still holds p.
+			r.clear(top, p)
+			if cur := d.ring.Load(); cur != r {
+				cur.clear(top, p)
+			}
+			return *p, true
+		}
+		// Another thief or the owner took it, try the next one.
+	}
+}
+
+// Size returns the number of elements in the deque, at the time of calling.
+func (d *WorkStealingDeque[T]) Size() int {
+	return int(max(d.bottom.Load()-d.top.Load(), 0))
+}
+
+// Empty returns true if the deque is empty, at the time of calling.
+func (d *WorkStealingDeque[T]) Empty() bool {
+	return d.Size() == 0
+}
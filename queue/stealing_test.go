@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package queue_test
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/queue"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkStealingDeque(t *testing.T) {
+	d := queue.NewWorkStealingDeque[int]()
+	assert.True(t, d.Empty())
+	_, ok := d.Pop()
+	assert.False(t, ok)
+	_, ok = d.Steal()
+	assert.False(t, ok)
+
+	// past the initial buffer
+	for i := 0; i < 100; i++ {
+		d.Push(i)
+	}
+	assert.Equal(t, 100, d.Size())
+	v, ok := d.Steal()
+	assert.True(t, ok)
+	assert.Equal(t, 0, v)
+	v, ok = d.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 99, v)
+
+	for i := 98; i >= 1; i-- {
+		v, ok = d.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+	_, ok = d.Pop()
+	assert.False(t, ok)
+	assert.True(t, d.Empty())
+
+	// still usable once drained
+	d.Push(7)
+	v, ok = d.Steal()
+	assert.True(t, ok)
+	assert.Equal(t, 7, v)
+}
+
+func TestWorkStealingDequeReleasesStolen(t *testing.T) {
+	const n = 10
+	var finalized atomic.Int32
+	d := queue.NewWorkStealingDeque[*[64]byte]()
+	for i := 0; i < n; i++ {
+		v := new([64]byte)
+		runtime.SetFinalizer(v, func(*[64]byte) { finalized.Add(1) })
+		d.Push(v)
+	}
+	for i := 0; i < n; i++ {
+		_, ok := d.Steal()
+		assert.True(t, ok)
+	}
+	// the ring does not wrap around, the slots must be cleared by Steal
+	assert.Eventually(t, func() bool {
+		runtime.GC()
+		return finalized.Load() == n
+	}, 5*time.Second, 10*time.Millisecond)
+	runtime.KeepAlive(d)
+}
+
+func TestWorkStealingDequeStress(t *testing.T) {
+	const n, thieves = 50000, 4
+	d := queue.NewWorkStealingDeque[int]()
+	taken := make([]atomic.Int32, n)
+	var done atomic.Bool
+	var wg sync.WaitGroup
+	stolen := make([][]int, thieves)
+	for i := 0; i < thieves; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !done.Load() || !d.Empty() {
+				if v, ok := d.Steal(); ok {
+					stolen[i] = append(stolen[i], v)
+				}
+			}
+		}()
+	}
+
+	// the owner pushes in bursts, growing the buffer, and pops some back
+	var popped []int
+	for i := 0; i < n; {
+		burst := min(1+i%100, n-i)
+		for j := 0; j < burst; j++ {
+			d.Push(i)
+			i++
+		}
+		for j := 0; j < burst/3; j++ {
+			if v, ok := d.Pop(); ok {
+				popped = append(popped, v)
+			}
+		}
+	}
+	for {
+		v, ok := d.Pop()
+		if !ok {
+			break
+		}
+		popped = append(popped, v)
+	}
+	done.Store(true)
+	wg.Wait()
+
+	for _, v := range popped {
+		taken[v].Add(1)
+	}
+	for _, s := range stolen {
+		for j, v := range s {
+			taken[v].Add(1)
+			// the top only moves forward: each thief steals in push order
+			if j > 0 {
+				assert.Greater(t, v, s[j-1])
+			}
+		}
+	}
+	for v := range taken {
+		if n := taken[v].Load(); n != 1 {
+			t.Fatalf("%d taken %d times", v, n)
+		}
+	}
+}
+
+func TestWorkStealingDequeLastElement(t *testing.T) {
+	// the owner and a thief race for a lone element: exactly one gets it
+	d := queue.NewWorkStealingDeque[int]()
+	for round := 0; round < 5000; round++ {
+		d.Push(round)
+		var thief atomic.Bool
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			v, ok := d.Steal()
+			if ok {
+				assert.Equal(t, round, v)
+			}
+			thief.Store(ok)
+		}()
+		close(start)
+		v, owner := d.Pop()
+		wg.Wait()
+		if owner {
+			assert.Equal(t, round, v)
+		}
+		assert.NotEqual(t, owner, thief.Load(), "round %d", round)
+		assert.True(t, d.Empty())
+	}
+}
+
+func BenchmarkWorkStealingDeque(b *testing.B) {
+	d := queue.NewWorkStealingDeque[int]()
+	var done atomic.Bool
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for !done.Load() {
+			d.Steal()
+		}
+	}()
+	for i := 0; i < b.N; i++ {
+		d.Push(i)
+		if i%2 == 0 {
+			d.Pop()
+		}
+	}
+	done.Store(true)
+	wg.Wait()
+}
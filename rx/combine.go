@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rx
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+)
+
+// Merge returns an Observable emitting the values of all the Observables as they arrive.
+// It completes once all of them have completed, and fails as soon as any of them fails.
+func Merge[T any](obs ...Observable[T]) Observable[T] {
+	return Create(func(ctx context.Context, o Observer[T]) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		out := serialize(o, cancel)
+		var wg sync.WaitGroup
+		for _, ob := range obs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ob.produce(ctx, NewObserver(out.OnNext, out.OnError, nil))
+			}()
+		}
+		wg.Wait()
+		if ctx.Err() == nil {
+			out.OnComplete()
+		}
+	})
+}
+
+// Zip returns an Observable pairing up the values of the two Observables by their index.
+// It completes once either of them has completed and all its values are paired.
+//
+//	rx.Zip(rx.Of(1, 2, 3), rx.Of("a", "b")) // (1, "a"), (2, "b")
+func Zip[A, B any](a Observable[A], b Observable[B]) Observable[functional.Pair[A, B]] {
+	return Create(func(ctx context.Context, o Observer[functional.Pair[A, B]]) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		out := serialize(o, cancel)
+		var (
+			lock         sync.Mutex
+			as           []A
+			bs           []B
+			aDone, bDone bool
+		)
+		// emit pairs up the buffered values, it must be called with the lock held.
+		emit := func() {
+			for len(as) > 0 && len(bs) > 0 {
+				p := functional.PairOf(as[0], bs[0])
+				as, bs = as[1:], bs[1:]
+				out.OnNext(p)
+			}
+			if (aDone && len(as) == 0) || (bDone && len(bs) == 0) {
+				out.OnComplete()
+			}
+		}
+		locked := func(fn func()) {
+			lock.Lock()
+			defer lock.Unlock()
+			fn()
+			emit()
+		}
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			a.produce(ctx, NewObserver(
+				func(v A) { locked(func() { as = append(as, v) }) },
+				out.OnError,
+				func() { locked(func() { aDone = true }) },
+			))
+		}()
+		go func() {
+			defer wg.Done()
+			b.produce(ctx, NewObserver(
+				func(v B) { locked(func() { bs = append(bs, v) }) },
+				out.OnError,
+				func() { locked(func() { bDone = true }) },
+			))
+		}()
+		wg.Wait()
+	})
+}
+
+// CombineLatest returns an Observable emitting the latest values of the two Observables
+// each time either of them emits, once both have emitted at least once.
+// It completes once both of them have completed, and fails as soon as either fails.
+func CombineLatest[A, B any](a Observable[A], b Observable[B]) Observable[functional.Pair[A, B]] {
+	return Create(func(ctx context.Context, o Observer[functional.Pair[A, B]]) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		out := serialize(o, cancel)
+		var (
+			lock         sync.Mutex
+			lastA        A
+			lastB        B
+			hasA, hasB   bool
+			aDone, bDone bool
+		)
+		update := func(fn func()) {
+			lock.Lock()
+			defer lock.Unlock()
+			fn()
+			if hasA && hasB {
+				out.OnNext(functional.PairOf(lastA, lastB))
+			}
+		}
+		finish := func(fn func()) {
+			lock.Lock()
+			defer lock.Unlock()
+			fn()
+			// one of them completing empty means there will never be a pair
+			if (aDone && bDone) || (aDone && !hasA) || (bDone && !hasB) {
+				out.OnComplete()
+			}
+		}
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			a.produce(ctx, NewObserver(
+				func(v A) { update(func() { lastA, hasA = v, true }) },
+				out.OnError,
+				func() { finish(func() { aDone = true }) },
+			))
+		}()
+		go func() {
+			defer wg.Done()
+			b.produce(ctx, NewObserver(
+				func(v B) { update(func() { lastB, hasB = v, true }) },
+				out.OnError,
+				func() { finish(func() { bDone = true }) },
+			))
+		}()
+		wg.Wait()
+	})
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rx_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/rx"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	values, err := rx.Merge(rx.Of(1, 2), rx.Of(3), rx.Empty[int]()).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int{1, 2, 3}, values)
+
+	_, err = rx.Merge(rx.Never[int](), rx.Throw[int](errBoom)).Collect(context.Background())
+	assert.ErrorIs(t, err, errBoom)
+
+	values, err = rx.Merge[int]().Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+}
+
+func TestZip(t *testing.T) {
+	values, err := rx.Zip(rx.Of(1, 2, 3), rx.Of("a", "b")).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []functional.Pair[int, string]{
+		functional.PairOf(1, "a"),
+		functional.PairOf(2, "b"),
+	}, values)
+
+	// completes as soon as the shorter side is exhausted, even against an infinite one
+	values2, err := rx.Zip(rx.Interval(time.Millisecond), rx.Of("x")).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []functional.Pair[int, string]{functional.PairOf(0, "x")}, values2)
+
+	_, err = rx.Zip(rx.Never[int](), rx.Throw[int](errBoom)).Collect(context.Background())
+	assert.ErrorIs(t, err, errBoom)
+}
+
+func TestCombineLatest(t *testing.T) {
+	a := rx.NewSubject[int]()
+	b := rx.NewSubject[string]()
+	sub, values := subscribed(rx.CombineLatest(a.Observable(), b.Observable()))
+	time.Sleep(10 * time.Millisecond)
+	a.OnNext(1)
+	a.OnNext(2)
+	b.OnNext("x")
+	a.OnNext(3)
+	b.OnNext("y")
+	a.OnComplete()
+	b.OnNext("z")
+	b.OnComplete()
+	<-sub.Done()
+	assert.Equal(t, []functional.Pair[int, string]{
+		functional.PairOf(2, "x"),
+		functional.PairOf(3, "x"),
+		functional.PairOf(3, "y"),
+		functional.PairOf(3, "z"),
+	}, values())
+
+	// one side completing empty means there is nothing to combine
+	values2, err := rx.CombineLatest(rx.Empty[int](), rx.Never[int]()).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, values2)
+}
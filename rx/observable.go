@@ -0,0 +1,187 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rx
+
+import (
+	"context"
+	"sync"
+)
+
+// Observer receives the notifications of an Observable:
+// any number of OnNext, then at most one of OnError or OnComplete.
+type Observer[T any] interface {
+	OnNext(T)
+	OnError(error)
+	OnComplete()
+}
+
+// funcObserver is an Observer built from functions.
+type funcObserver[T any] struct {
+	next     func(T)
+	err      func(error)
+	complete func()
+}
+
+// NewObserver creates an Observer from functions, nil functions ignore their notification.
+func NewObserver[T any](next func(T), err func(error), complete func()) Observer[T] {
+	return funcObserver[T]{next: next, err: err, complete: complete}
+}
+
+// OnNext implements Observer.
+func (o funcObserver[T]) OnNext(v T) {
+	if o.next != nil {
+		o.next(v)
+	}
+}
+
+// OnError implements Observer.
+func (o funcObserver[T]) OnError(err error) {
+	if o.err != nil {
+		o.err(err)
+	}
+}
+
+// OnComplete implements Observer.
+func (o funcObserver[T]) OnComplete() {
+	if o.complete != nil {
+		o.complete()
+	}
+}
+
+// Observable is a lazy push-based sequence: nothing happens until it is subscribed.
+//
+// It is defined by its producer, which pushes the notifications to the Observer
+// and returns once it has terminated or once ctx is done.
+// Every Observable in this package notifies its Observer serially, from one goroutine at a time.
+//
+//	squares, err := rx.Map(rx.Of(1, 2, 3).Filter(isOdd), square).Collect(ctx) // [1, 9]
+type Observable[T any] struct {
+	produce func(ctx context.Context, o Observer[T])
+}
+
+// Create returns an Observable from a producer.
+// The producer must notify the Observer serially, and return once it has terminated
+// or as soon as possible after ctx is done.
+func Create[T any](produce func(ctx context.Context, o Observer[T])) Observable[T] {
+	return Observable[T]{produce: produce}
+}
+
+// Subscribe starts the Observable in a new goroutine and pushes its notifications to the Observer.
+// Disposing the Subscription, or cancelling ctx, stops the Observable.
+func (ob Observable[T]) Subscribe(ctx context.Context, o Observer[T]) Subscription {
+	ctx, cancel := context.WithCancel(ctx)
+	sub := &subscription{cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(sub.done)
+		defer cancel()
+		ob.produce(ctx, serialize(o, cancel))
+	}()
+	return sub
+}
+
+// Collect subscribes to the Observable, waits for it to stop,
+// and returns the values it emitted along with its error.
+// If ctx is done before the Observable terminates, it returns ctx.Err().
+func (ob Observable[T]) Collect(ctx context.Context) ([]T, error) {
+	var values []T
+	var err error
+	terminated := false
+	sub := ob.Subscribe(ctx, NewObserver(
+		func(v T) { values = append(values, v) },
+		func(e error) { err, terminated = e, true },
+		func() { terminated = true },
+	))
+	<-sub.Done()
+	if !terminated {
+		return values, ctx.Err()
+	}
+	return values, err
+}
+
+// Subscription is the handle of a subscribed Observable.
+type Subscription interface {
+	// Dispose stops the Observable, it is safe to call more than once.
+	Dispose()
+	// Done returns a channel that is closed once the Observable has stopped,
+	// either terminated or disposed. No notification is delivered after that.
+	Done() <-chan struct{}
+}
+
+// subscription implements Subscription.
+type subscription struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Dispose implements Subscription.
+func (s *subscription) Dispose() {
+	s.cancel()
+}
+
+// Done implements Subscription.
+func (s *subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// safeObserver enforces the Observer contract:
+// the notifications are serialized, and nothing is delivered after a terminal one.
+// A terminal notification cancels the subscription, which stops the upstream.
+type safeObserver[T any] struct {
+	lock   sync.Mutex
+	o      Observer[T]
+	done   bool
+	cancel context.CancelFunc
+}
+
+// OnNext implements Observer.
+func (s *safeObserver[T]) OnNext(v T) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.done {
+		s.o.OnNext(v)
+	}
+}
+
+// OnError implements Observer.
+func (s *safeObserver[T]) OnError(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.done {
+		s.done = true
+		s.o.OnError(err)
+		s.cancel()
+	}
+}
+
+// OnComplete implements Observer.
+func (s *safeObserver[T]) OnComplete() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.done {
+		s.done = true
+		s.o.OnComplete()
+		s.cancel()
+	}
+}
+
+// serialize wraps the Observer, cancel is called after the terminal notification.
+// Operators emitting from several goroutines use it to keep the notifications serial.
+func serialize[T any](o Observer[T], cancel context.CancelFunc) *safeObserver[T] {
+	return &safeObserver[T]{o: o, cancel: cancel}
+}
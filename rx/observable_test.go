@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rx_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/rx"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errBoom = errors.New("boom")
+
+// noLeaks fails the test if it leaves more goroutines running than it started with.
+func noLeaks(t *testing.T) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		if after := runtime.NumGoroutine(); after > before {
+			buf := make([]byte, 1<<16)
+			t.Errorf("leaked %d goroutines:\n%s", after-before, buf[:runtime.Stack(buf, true)])
+		}
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	var values []int
+	completed := false
+	sub := rx.Of(1, 2, 3).Subscribe(context.Background(), rx.NewObserver(
+		func(v int) { values = append(values, v) },
+		func(error) { t.Error("unexpected error") },
+		func() { completed = true },
+	))
+	<-sub.Done()
+	assert.Equal(t, []int{1, 2, 3}, values)
+	assert.True(t, completed)
+}
+
+func TestDispose(t *testing.T) {
+	var count atomic.Int32
+	sub := rx.Interval(time.Millisecond).Subscribe(context.Background(), rx.NewObserver(
+		func(int) { count.Add(1) }, nil, nil,
+	))
+	time.Sleep(20 * time.Millisecond)
+	sub.Dispose()
+	sub.Dispose()
+	<-sub.Done()
+	seen := count.Load()
+	assert.Greater(t, seen, int32(0))
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, seen, count.Load())
+}
+
+func TestCollect(t *testing.T) {
+	values, err := rx.Of("a", "b").Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, values)
+
+	_, err = rx.Throw[int](errBoom).Collect(context.Background())
+	assert.ErrorIs(t, err, errBoom)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = rx.Never[int]().Collect(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestObserverContract(t *testing.T) {
+	// a misbehaving source is kept in line
+	bad := rx.Create(func(_ context.Context, o rx.Observer[int]) {
+		o.OnNext(1)
+		o.OnComplete()
+		o.OnNext(2)
+		o.OnError(errBoom)
+		o.OnComplete()
+	})
+	var values []int
+	terminals := 0
+	sub := bad.Subscribe(context.Background(), rx.NewObserver(
+		func(v int) { values = append(values, v) },
+		func(error) { terminals++ },
+		func() { terminals++ },
+	))
+	<-sub.Done()
+	assert.Equal(t, []int{1}, values)
+	assert.Equal(t, 1, terminals)
+}
@@ -0,0 +1,344 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rx
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Map returns an Observable emitting the results of applying the function to each value.
+func Map[T, U any](ob Observable[T], fn func(T) U) Observable[U] {
+	return Create(func(ctx context.Context, o Observer[U]) {
+		ob.produce(ctx, NewObserver(
+			func(v T) { o.OnNext(fn(v)) },
+			o.OnError,
+			o.OnComplete,
+		))
+	})
+}
+
+// Filter returns an Observable emitting the values satisfying the predicate.
+func (ob Observable[T]) Filter(p func(T) bool) Observable[T] {
+	return Create(func(ctx context.Context, o Observer[T]) {
+		ob.produce(ctx, NewObserver(
+			func(v T) {
+				if p(v) {
+					o.OnNext(v)
+				}
+			},
+			o.OnError,
+			o.OnComplete,
+		))
+	})
+}
+
+// Take returns an Observable emitting the first n values, then completing.
+// It is the usual way to bound an infinite Observable.
+func (ob Observable[T]) Take(n int) Observable[T] {
+	return Create(func(ctx context.Context, o Observer[T]) {
+		if n <= 0 {
+			o.OnComplete()
+			return
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		out := serialize(o, cancel)
+		count := 0
+		ob.produce(ctx, NewObserver(
+			func(v T) {
+				out.OnNext(v)
+				if count++; count == n {
+					out.OnComplete()
+				}
+			},
+			out.OnError,
+			out.OnComplete,
+		))
+	})
+}
+
+// FlatMap maps each value to an Observable and merges their values, in the order they are emitted.
+// It completes once the source and all the inner Observables have completed,
+// and fails as soon as any of them fails.
+func FlatMap[T, U any](ob Observable[T], fn func(T) Observable[U]) Observable[U] {
+	return Create(func(ctx context.Context, o Observer[U]) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		out := serialize(o, cancel)
+		var wg sync.WaitGroup
+		completed := false
+		ob.produce(ctx, NewObserver(
+			func(v T) {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					fn(v).produce(ctx, NewObserver(out.OnNext, out.OnError, nil))
+				}()
+			},
+			out.OnError,
+			func() { completed = true },
+		))
+		wg.Wait()
+		if completed && ctx.Err() == nil {
+			out.OnComplete()
+		}
+	})
+}
+
+// ConcatMap maps each value to an Observable and concatenates them:
+// an inner Observable is subscribed once the previous one has completed.
+func ConcatMap[T, U any](ob Observable[T], fn func(T) Observable[U]) Observable[U] {
+	return Create(func(ctx context.Context, o Observer[U]) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		out := serialize(o, cancel)
+		ob.produce(ctx, NewObserver(
+			func(v T) {
+				fn(v).produce(ctx, NewObserver(out.OnNext, out.OnError, nil))
+			},
+			out.OnError,
+			func() {
+				if ctx.Err() == nil {
+					out.OnComplete()
+				}
+			},
+		))
+	})
+}
+
+// SwitchMap maps each value to an Observable and emits the values of the latest one only:
+// a new value from the source disposes the previous inner Observable.
+func SwitchMap[T, U any](ob Observable[T], fn func(T) Observable[U]) Observable[U] {
+	return Create(func(ctx context.Context, o Observer[U]) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		out := serialize(o, cancel)
+		var (
+			lock        sync.Mutex
+			wg          sync.WaitGroup
+			latest      int
+			cancelInner context.CancelFunc = func() {}
+			completed   bool
+		)
+		ob.produce(ctx, NewObserver(
+			func(v T) {
+				lock.Lock()
+				cancelInner()
+				latest++
+				gen := latest
+				var inner context.Context
+				inner, cancelInner = context.WithCancel(ctx)
+				lock.Unlock()
+
+				// only the latest inner Observable may notify
+				current := func(fn func()) {
+					lock.Lock()
+					defer lock.Unlock()
+					if gen == latest {
+						fn()
+					}
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					fn(v).produce(inner, NewObserver(
+						func(u U) { current(func() { out.OnNext(u) }) },
+						func(err error) { current(func() { out.OnError(err) }) },
+						nil,
+					))
+				}()
+			},
+			out.OnError,
+			func() { completed = true },
+		))
+		wg.Wait()
+		lock.Lock()
+		cancelInner()
+		lock.Unlock()
+		if completed && ctx.Err() == nil {
+			out.OnComplete()
+		}
+	})
+}
+
+// Debounce returns an Observable emitting a value only once d has passed without another value.
+// The last pending value is emitted when the source completes.
+func (ob Observable[T]) Debounce(d time.Duration) Observable[T] {
+	return Create(func(ctx context.Context, o Observer[T]) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		out := serialize(o, cancel)
+		var (
+			lock    sync.Mutex
+			timer   *time.Timer
+			latest  int
+			pending T
+			has     bool
+		)
+		// stop drops the pending value, it must be called with the lock held.
+		stop := func() {
+			latest++
+			has = false
+			if timer != nil {
+				timer.Stop()
+			}
+		}
+		ob.produce(ctx, NewObserver(
+			func(v T) {
+				lock.Lock()
+				defer lock.Unlock()
+				stop()
+				pending, has = v, true
+				gen := latest
+				timer = time.AfterFunc(d, func() {
+					lock.Lock()
+					defer lock.Unlock()
+					if gen == latest && has {
+						has = false
+						out.OnNext(pending)
+					}
+				})
+			},
+			func(err error) {
+				lock.Lock()
+				defer lock.Unlock()
+				stop()
+				out.OnError(err)
+			},
+			func() {
+				lock.Lock()
+				defer lock.Unlock()
+				if has {
+					out.OnNext(pending)
+				}
+				stop()
+				out.OnComplete()
+			},
+		))
+		lock.Lock()
+		stop()
+		lock.Unlock()
+	})
+}
+
+// Throttle returns an Observable emitting a value, then ignoring the following ones for d.
+func (ob Observable[T]) Throttle(d time.Duration) Observable[T] {
+	return Create(func(ctx context.Context, o Observer[T]) {
+		var next time.Time
+		ob.produce(ctx, NewObserver(
+			func(v T) {
+				if now := time.Now(); !now.Before(next) {
+					next = now.Add(d)
+					o.OnNext(v)
+				}
+			},
+			o.OnError,
+			o.OnComplete,
+		))
+	})
+}
+
+// Buffer returns an Observable emitting the values in slices of count elements.
+// The last slice, emitted when the source completes, may be shorter.
+// A count less than 1 is treated as 1.
+//
+//	rx.Buffer(rx.Of(1, 2, 3, 4, 5), 2) // [1, 2], [3, 4], [5]
+func Buffer[T any](ob Observable[T], count int) Observable[[]T] {
+	count = max(count, 1)
+	return Create(func(ctx context.Context, o Observer[[]T]) {
+		buf := make([]T, 0, count)
+		ob.produce(ctx, NewObserver(
+			func(v T) {
+				if buf = append(buf, v); len(buf) == count {
+					o.OnNext(buf)
+					buf = make([]T, 0, count)
+				}
+			},
+			o.OnError,
+			func() {
+				if len(buf) > 0 {
+					o.OnNext(buf)
+				}
+				o.OnComplete()
+			},
+		))
+	})
+}
+
+// Window is like Buffer, but emits each group as an Observable as soon as it opens.
+// Each window replays its values to late subscribers.
+// A count less than 1 is treated as 1.
+func Window[T any](ob Observable[T], count int) Observable[Observable[T]] {
+	count = max(count, 1)
+	return Create(func(ctx context.Context, o Observer[Observable[T]]) {
+		var window *Subject[T]
+		size := 0
+		ob.produce(ctx, NewObserver(
+			func(v T) {
+				if window == nil {
+					window = NewReplaySubject[T]()
+					o.OnNext(window.Observable())
+				}
+				window.OnNext(v)
+				if size++; size == count {
+					window.OnComplete()
+					window, size = nil, 0
+				}
+			},
+			func(err error) {
+				if window != nil {
+					window.OnError(err)
+					window = nil
+				}
+				o.OnError(err)
+			},
+			func() {
+				if window != nil {
+					window.OnComplete()
+					window = nil
+				}
+				o.OnComplete()
+			},
+		))
+		if window != nil {
+			// disposed while a window is open
+			window.OnError(ctx.Err())
+		}
+	})
+}
+
+// Retry returns an Observable resubscribing to the source when it fails, up to n times.
+// The values emitted before each failure are passed on.
+func (ob Observable[T]) Retry(n int) Observable[T] {
+	return Create(func(ctx context.Context, o Observer[T]) {
+		for attempt := 0; ; attempt++ {
+			var failed error
+			ob.produce(ctx, NewObserver(o.OnNext, func(err error) { failed = err }, o.OnComplete))
+			if failed == nil || ctx.Err() != nil {
+				return
+			}
+			if attempt >= n {
+				o.OnError(failed)
+				return
+			}
+		}
+	})
+}
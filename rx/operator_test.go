@@ -0,0 +1,192 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rx_test
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/rx"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapFilterTake(t *testing.T) {
+	isOdd := func(x int) bool { return x%2 == 1 }
+	values, err := rx.Map(rx.Of(1, 2, 3, 4, 5).Filter(isOdd), strconv.Itoa).Take(2).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "3"}, values)
+
+	values, err = rx.Map(rx.Of(1), strconv.Itoa).Take(0).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+}
+
+func TestFlatMap(t *testing.T) {
+	values, err := rx.FlatMap(rx.Of(1, 2, 3), func(x int) rx.Observable[int] {
+		return rx.Of(x, x*10)
+	}).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int{1, 10, 2, 20, 3, 30}, values)
+
+	// infinite inner Observables are stopped by Take
+	values, err = rx.FlatMap(rx.Of(1, 2), func(x int) rx.Observable[int] {
+		return rx.Map(rx.Interval(time.Millisecond), func(int) int { return x })
+	}).Take(5).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, values, 5)
+
+	_, err = rx.FlatMap(rx.Of(1, 2), func(x int) rx.Observable[int] {
+		if x == 2 {
+			return rx.Throw[int](errBoom)
+		}
+		return rx.Never[int]()
+	}).Collect(context.Background())
+	assert.ErrorIs(t, err, errBoom)
+}
+
+func TestConcatMap(t *testing.T) {
+	values, err := rx.ConcatMap(rx.Of(1, 2, 3), func(x int) rx.Observable[int] {
+		// the first inner Observable is the slowest, yet its values come first
+		return rx.Map(rx.Interval(time.Duration(4-x)*time.Millisecond).Take(2), func(i int) int {
+			return x*10 + i
+		})
+	}).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{10, 11, 20, 21, 30, 31}, values)
+
+	values, err = rx.ConcatMap(rx.Of(1, 2, 3), func(x int) rx.Observable[int] {
+		if x == 2 {
+			return rx.Throw[int](errBoom)
+		}
+		return rx.Of(x)
+	}).Collect(context.Background())
+	assert.ErrorIs(t, err, errBoom)
+	assert.Equal(t, []int{1}, values)
+}
+
+func TestSwitchMap(t *testing.T) {
+	s := rx.NewSubject[int]()
+	ob := rx.SwitchMap(s.Observable(), func(x int) rx.Observable[int] {
+		return rx.Map(rx.Interval(5*time.Millisecond), func(i int) int { return x*100 + i })
+	})
+	sub, values := subscribed(ob)
+	time.Sleep(5 * time.Millisecond)
+	s.OnNext(1)
+	time.Sleep(30 * time.Millisecond)
+	s.OnNext(2)
+	time.Sleep(30 * time.Millisecond)
+	sub.Dispose()
+	<-sub.Done()
+
+	got := values()
+	assert.NotEmpty(t, got)
+	// once switched to 2, nothing comes from 1 anymore
+	switched := false
+	for _, v := range got {
+		if v >= 200 {
+			switched = true
+		} else {
+			assert.False(t, switched, "value %d after the switch", v)
+		}
+	}
+	assert.True(t, switched)
+
+	values2, err := rx.SwitchMap(rx.Of(1, 2), func(x int) rx.Observable[int] {
+		return rx.Of(x)
+	}).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.NotEmpty(t, values2)
+}
+
+func TestDebounce(t *testing.T) {
+	s := rx.NewSubject[int]()
+	sub, values := subscribed(s.Observable().Debounce(20 * time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	s.OnNext(1)
+	s.OnNext(2)
+	s.OnNext(3)
+	time.Sleep(60 * time.Millisecond)
+	s.OnNext(4)
+	s.OnNext(5)
+	s.OnComplete() // flushes 5
+	<-sub.Done()
+	assert.Equal(t, []int{3, 5}, values())
+}
+
+func TestThrottle(t *testing.T) {
+	s := rx.NewSubject[int]()
+	sub, values := subscribed(s.Observable().Throttle(30 * time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	s.OnNext(1)
+	s.OnNext(2)
+	time.Sleep(50 * time.Millisecond)
+	s.OnNext(3)
+	s.OnNext(4)
+	s.OnComplete()
+	<-sub.Done()
+	assert.Equal(t, []int{1, 3}, values())
+}
+
+func TestBuffer(t *testing.T) {
+	values, err := rx.Buffer(rx.Of(1, 2, 3, 4, 5), 2).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, values)
+
+	values, err = rx.Buffer(rx.Of(1, 2), 0).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{1}, {2}}, values)
+}
+
+func TestWindow(t *testing.T) {
+	windows, err := rx.Window(rx.Of(1, 2, 3, 4, 5), 2).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, windows, 3)
+	var got [][]int
+	for _, w := range windows {
+		values, err := w.Collect(context.Background())
+		assert.NoError(t, err)
+		got = append(got, values)
+	}
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, got)
+}
+
+func TestRetry(t *testing.T) {
+	var attempts atomic.Int32
+	flaky := rx.Create(func(_ context.Context, o rx.Observer[int]) {
+		n := attempts.Add(1)
+		o.OnNext(int(n))
+		if n < 3 {
+			o.OnError(errBoom)
+			return
+		}
+		o.OnComplete()
+	})
+	values, err := flaky.Retry(5).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, values)
+
+	attempts.Store(0)
+	values, err = flaky.Retry(1).Collect(context.Background())
+	assert.ErrorIs(t, err, errBoom)
+	assert.Equal(t, []int{1, 2}, values)
+}
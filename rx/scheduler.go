@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rx
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mizumoto-cn/fpkit/queue"
+)
+
+// Scheduler decides where a task runs.
+type Scheduler interface {
+	// Schedule runs the task, now or later, on the calling goroutine or another one.
+	Schedule(task func())
+}
+
+// SchedulerFunc is a function implementing Scheduler.
+type SchedulerFunc func(task func())
+
+// Schedule implements Scheduler.
+func (f SchedulerFunc) Schedule(task func()) {
+	f(task)
+}
+
+// Immediate runs the tasks at once on the calling goroutine.
+var Immediate Scheduler = SchedulerFunc(func(task func()) {
+	task()
+})
+
+// Goroutine runs each task on a new goroutine.
+var Goroutine Scheduler = SchedulerFunc(func(task func()) {
+	go task()
+})
+
+// SubscribeOn returns an Observable whose source runs on the Scheduler.
+func (ob Observable[T]) SubscribeOn(s Scheduler) Observable[T] {
+	return Create(func(ctx context.Context, o Observer[T]) {
+		done := make(chan struct{})
+		s.Schedule(func() {
+			defer close(done)
+			ob.produce(ctx, o)
+		})
+		<-done
+	})
+}
+
+// notification is a value or a terminal notification passed through an ObserveOn buffer.
+type notification[T any] struct {
+	value    T
+	err      error
+	terminal bool
+}
+
+// ObserveOn returns an Observable notifying its Observer on the Scheduler.
+// The notifications go through a bounded ArrayBlockingQueue of bufferSize elements:
+// when the Observer falls behind and the buffer is full, the source is blocked,
+// which gives backpressure. A bufferSize less than 1 is treated as 1.
+// The source is stopped and waited for before the Observable terminates.
+func (ob Observable[T]) ObserveOn(s Scheduler, bufferSize int) Observable[T] {
+	return Create(func(ctx context.Context, o Observer[T]) {
+		ctx, cancel := context.WithCancel(ctx)
+		var producing sync.WaitGroup
+		defer producing.Wait()
+		defer cancel()
+		buf := queue.NewArrayBlockingQueue[notification[T]](max(bufferSize, 1))
+		producing.Add(1)
+		go func() {
+			defer producing.Done()
+			ob.produce(ctx, NewObserver(
+				func(v T) { _ = buf.Push(ctx, notification[T]{value: v}) },
+				func(err error) { _ = buf.Push(ctx, notification[T]{err: err, terminal: true}) },
+				func() { _ = buf.Push(ctx, notification[T]{terminal: true}) },
+			))
+		}()
+
+		done := make(chan struct{})
+		s.Schedule(func() {
+			defer close(done)
+			for {
+				n, err := buf.TryPop(ctx)
+				if err != nil {
+					return
+				}
+				if n.terminal {
+					notifyTerminal(o, n.err)
+					return
+				}
+				o.OnNext(n.value)
+			}
+		})
+		<-done
+	})
+}
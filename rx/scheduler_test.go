@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rx_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/rx"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeOn(t *testing.T) {
+	for _, s := range []rx.Scheduler{rx.Immediate, rx.Goroutine} {
+		values, err := rx.Of(1, 2, 3).SubscribeOn(s).Collect(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, values)
+	}
+}
+
+func TestObserveOn(t *testing.T) {
+	for _, s := range []rx.Scheduler{rx.Immediate, rx.Goroutine} {
+		values, err := rx.Of(1, 2, 3).ObserveOn(s, 1).Collect(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, values)
+
+		_, err = rx.Throw[int](errBoom).ObserveOn(s, 0).Collect(context.Background())
+		assert.ErrorIs(t, err, errBoom)
+	}
+}
+
+func TestObserveOnStopsSource(t *testing.T) {
+	noLeaks(t)
+	var running atomic.Bool
+	source := rx.Create(func(ctx context.Context, o rx.Observer[int]) {
+		running.Store(true)
+		defer running.Store(false)
+		for i := 0; ctx.Err() == nil; i++ {
+			o.OnNext(i)
+			time.Sleep(time.Millisecond)
+		}
+	})
+	for _, s := range []rx.Scheduler{rx.Immediate, rx.Goroutine} {
+		values, err := source.ObserveOn(s, 2).Take(3).Collect(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2}, values)
+		// the source is done once the subscription is
+		assert.False(t, running.Load())
+	}
+}
+
+func TestObserveOnBackpressure(t *testing.T) {
+	var produced atomic.Int32
+	source := rx.Create(func(ctx context.Context, o rx.Observer[int]) {
+		for i := 0; ctx.Err() == nil; i++ {
+			produced.Add(1)
+			o.OnNext(i)
+		}
+	})
+	const buffer = 4
+	release := make(chan struct{})
+	sub := source.ObserveOn(rx.Goroutine, buffer).Subscribe(context.Background(), rx.NewObserver(
+		func(int) { <-release }, nil, nil,
+	))
+	time.Sleep(20 * time.Millisecond)
+	// one value held by the slow observer, the buffer full, and one blocked on Push
+	assert.LessOrEqual(t, produced.Load(), int32(buffer+2))
+	sub.Dispose()
+	close(release)
+	<-sub.Done()
+}
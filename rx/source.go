@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rx
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/queue"
+)
+
+// Of returns a cold Observable emitting the given values, then completing.
+func Of[T any](v ...T) Observable[T] {
+	return FromSlice(v)
+}
+
+// FromSlice returns a cold Observable emitting the elements of the slice, then completing.
+func FromSlice[T any](s []T) Observable[T] {
+	return Create(func(ctx context.Context, o Observer[T]) {
+		for _, v := range s {
+			if ctx.Err() != nil {
+				return
+			}
+			o.OnNext(v)
+		}
+		o.OnComplete()
+	})
+}
+
+// FromChan returns an Observable emitting the values received from the channel,
+// completing when the channel is closed.
+// It is hot: the values are shared by, not replayed to, the subscribers.
+func FromChan[T any](ch <-chan T) Observable[T] {
+	return Create(func(ctx context.Context, o Observer[T]) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-ch:
+				if !ok {
+					o.OnComplete()
+					return
+				}
+				o.OnNext(v)
+			}
+		}
+	})
+}
+
+// FromQueue returns an Observable emitting the elements popped from the queue.
+// It is hot: the elements are shared by, not replayed to, the subscribers.
+// It completes once the queue is closed and drained,
+// and fails with the other errors of TryPop, unless the subscription is disposed.
+func FromQueue[T any](q queue.BlockingQueue[T]) Observable[T] {
+	return Create(func(ctx context.Context, o Observer[T]) {
+		for {
+			v, err := q.TryPop(ctx)
+			if errors.Is(err, queue.ErrQueueClosed) {
+				o.OnComplete()
+				return
+			}
+			if err != nil {
+				if ctx.Err() == nil {
+					o.OnError(err)
+				}
+				return
+			}
+			o.OnNext(v)
+		}
+	})
+}
+
+// Interval returns a cold Observable emitting 0, 1, 2, ... every period. It never completes.
+func Interval(period time.Duration) Observable[int] {
+	return Create(func(ctx context.Context, o Observer[int]) {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				o.OnNext(i)
+			}
+		}
+	})
+}
+
+// Timer returns a cold Observable emitting 0 after the delay, then completing.
+func Timer(delay time.Duration) Observable[int] {
+	return Create(func(ctx context.Context, o Observer[int]) {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+			o.OnNext(0)
+			o.OnComplete()
+		}
+	})
+}
+
+// Empty returns an Observable completing without emitting anything.
+func Empty[T any]() Observable[T] {
+	return Create(func(_ context.Context, o Observer[T]) {
+		o.OnComplete()
+	})
+}
+
+// Never returns an Observable that never emits nor terminates.
+func Never[T any]() Observable[T] {
+	return Create(func(ctx context.Context, _ Observer[T]) {
+		<-ctx.Done()
+	})
+}
+
+// Throw returns an Observable failing with the error without emitting anything.
+func Throw[T any](err error) Observable[T] {
+	return Create(func(_ context.Context, o Observer[T]) {
+		o.OnError(err)
+	})
+}
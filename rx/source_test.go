@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rx_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/queue"
+	"github.com/mizumoto-cn/fpkit/rx"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromSlice(t *testing.T) {
+	ob := rx.FromSlice([]int{1, 2, 3})
+	// cold: every subscriber gets all the values
+	for range 2 {
+		values, err := ob.Collect(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, values)
+	}
+}
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	values, err := rx.FromChan(ch).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, values)
+}
+
+func TestFromQueue(t *testing.T) {
+	q := queue.NewArrayBlockingQueue[int](4)
+	for i := range 4 {
+		assert.NoError(t, q.Push(context.Background(), i))
+	}
+	values, err := rx.FromQueue[int](q).Take(4).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3}, values)
+	assert.Zero(t, q.Size())
+
+	// closing the queue completes the Observable once drained
+	assert.NoError(t, q.Push(context.Background(), 4))
+	q.Close()
+	values, err = rx.FromQueue[int](q).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4}, values)
+}
+
+func TestTimers(t *testing.T) {
+	values, err := rx.Interval(time.Millisecond).Take(3).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, values)
+
+	start := time.Now()
+	values, err = rx.Timer(10 * time.Millisecond).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0}, values)
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+}
+
+func TestEmptyThrow(t *testing.T) {
+	values, err := rx.Empty[int]().Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+
+	_, err = rx.Throw[int](errBoom).Collect(context.Background())
+	assert.ErrorIs(t, err, errBoom)
+}
@@ -0,0 +1,222 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rx
+
+import (
+	"context"
+	"sync"
+)
+
+// Subject is both an Observer and a hot Observable:
+// every notification it receives is multicast to its current subscribers.
+// The notifications are delivered outside of its lock, so an observer may notify or subscribe to the Subject again:
+// the notifications it sends meanwhile are queued, and delivered in order once the current one is.
+//
+//	s := rx.NewSubject[int]()
+//	sub := s.Observable().Subscribe(ctx, observer)
+//	s.OnNext(1) // delivered to observer
+//	s.OnComplete()
+type Subject[T any] struct {
+	lock      sync.Mutex
+	observers map[*subjectObserver[T]]struct{}
+	replay    bool
+	history   []T
+	done      bool
+	err       error
+	// emitting is true while a goroutine delivers the queue
+	emitting bool
+	queue    []subjectEvent[T]
+}
+
+// subjectObserver is a subscriber of a Subject.
+type subjectObserver[T any] struct {
+	o    Observer[T]
+	done chan struct{}
+	// disposed is set under the lock of the Subject once the subscriber is gone
+	disposed bool
+	// delivering is held while a notification is delivered to o
+	delivering sync.Mutex
+}
+
+// subjectEvent is a notification queued by a Subject.
+// A subscription is queued as well, to replay the history to the subscriber in order.
+type subjectEvent[T any] struct {
+	notification[T]
+	// subscriber is registered, after receiving values
+	subscriber *subjectObserver[T]
+	values     []T
+}
+
+var _ Observer[int] = (*Subject[int])(nil)
+
+// NewSubject creates a Subject delivering to each subscriber the notifications
+// received after it subscribed.
+func NewSubject[T any]() *Subject[T] {
+	return &Subject[T]{observers: make(map[*subjectObserver[T]]struct{})}
+}
+
+// NewReplaySubject creates a Subject replaying every value it has received to new subscribers.
+func NewReplaySubject[T any]() *Subject[T] {
+	s := NewSubject[T]()
+	s.replay = true
+	return s
+}
+
+// OnNext multicasts the value to the subscribers.
+func (s *Subject[T]) OnNext(v T) {
+	s.lock.Lock()
+	if s.done {
+		s.lock.Unlock()
+		return
+	}
+	if s.replay {
+		s.history = append(s.history, v)
+	}
+	s.emit(subjectEvent[T]{notification: notification[T]{value: v}})
+}
+
+// OnError multicasts the error to the subscribers, and terminates the Subject.
+func (s *Subject[T]) OnError(err error) {
+	s.terminate(err)
+}
+
+// OnComplete multicasts the completion to the subscribers, and terminates the Subject.
+func (s *Subject[T]) OnComplete() {
+	s.terminate(nil)
+}
+
+// terminate notifies and releases all the subscribers.
+func (s *Subject[T]) terminate(err error) {
+	s.lock.Lock()
+	if s.done {
+		s.lock.Unlock()
+		return
+	}
+	s.done, s.err = true, err
+	s.emit(subjectEvent[T]{notification: notification[T]{err: err, terminal: true}})
+}
+
+// emit queues the notification, and delivers the queue unless another goroutine is doing it.
+// It is called with the lock held, and releases it.
+func (s *Subject[T]) emit(e subjectEvent[T]) {
+	s.queue = append(s.queue, e)
+	if s.emitting {
+		s.lock.Unlock()
+		return
+	}
+	s.emitting = true
+	for len(s.queue) > 0 {
+		n := s.queue[0]
+		s.queue[0] = subjectEvent[T]{}
+		s.queue = s.queue[1:]
+		if so := n.subscriber; so != nil {
+			// a subscriber that has already given up is not registered
+			if !so.disposed {
+				s.observers[so] = struct{}{}
+			}
+			s.lock.Unlock()
+			s.deliver(so, func() {
+				for _, v := range n.values {
+					so.o.OnNext(v)
+				}
+			})
+			s.lock.Lock()
+			continue
+		}
+		observers := make([]*subjectObserver[T], 0, len(s.observers))
+		for so := range s.observers {
+			observers = append(observers, so)
+		}
+		if n.terminal {
+			clear(s.observers)
+		}
+		s.lock.Unlock()
+		for _, so := range observers {
+			if n.terminal {
+				s.deliver(so, func() { notifyTerminal(so.o, n.err) })
+				close(so.done)
+			} else {
+				s.deliver(so, func() { so.o.OnNext(n.value) })
+			}
+		}
+		s.lock.Lock()
+	}
+	s.queue = nil
+	s.emitting = false
+	s.lock.Unlock()
+}
+
+// deliver calls fn to notify the subscriber, unless it is disposed.
+// It holds delivering meanwhile, for dispose to wait for it.
+func (s *Subject[T]) deliver(so *subjectObserver[T], fn func()) {
+	so.delivering.Lock()
+	defer so.delivering.Unlock()
+	s.lock.Lock()
+	disposed := so.disposed
+	s.lock.Unlock()
+	if !disposed {
+		fn()
+	}
+}
+
+// dispose unregisters the subscriber, and waits for a delivery to it in progress,
+// so that it is notified no more once dispose returns.
+func (s *Subject[T]) dispose(so *subjectObserver[T]) {
+	s.lock.Lock()
+	so.disposed = true
+	delete(s.observers, so)
+	s.lock.Unlock()
+	so.delivering.Lock()
+	defer so.delivering.Unlock()
+}
+
+// notifyTerminal sends OnError, or OnComplete if err is nil.
+func notifyTerminal[T any](o Observer[T], err error) {
+	if err != nil {
+		o.OnError(err)
+	} else {
+		o.OnComplete()
+	}
+}
+
+// Observable returns the Subject as an Observable.
+// Subscribing to a terminated Subject replays its terminal notification.
+func (s *Subject[T]) Observable() Observable[T] {
+	return Create(func(ctx context.Context, o Observer[T]) {
+		s.lock.Lock()
+		history := append([]T(nil), s.history...)
+		if s.done {
+			err := s.err
+			s.lock.Unlock()
+			for _, v := range history {
+				o.OnNext(v)
+			}
+			notifyTerminal(o, err)
+			return
+		}
+		so := &subjectObserver[T]{o: o, done: make(chan struct{})}
+		s.emit(subjectEvent[T]{subscriber: so, values: history})
+
+		select {
+		case <-so.done:
+		case <-ctx.Done():
+			s.dispose(so)
+		}
+	})
+}
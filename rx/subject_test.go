@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rx_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/rx"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// subscribed collects the values of the Observable in the background.
+func subscribed[T any](ob rx.Observable[T]) (rx.Subscription, func() []T) {
+	var lock sync.Mutex
+	var values []T
+	sub := ob.Subscribe(context.Background(), rx.NewObserver(func(v T) {
+		lock.Lock()
+		defer lock.Unlock()
+		values = append(values, v)
+	}, nil, nil))
+	return sub, func() []T {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]T(nil), values...)
+	}
+}
+
+func TestSubject(t *testing.T) {
+	s := rx.NewSubject[int]()
+	s.OnNext(0) // nobody is listening
+
+	sub1, values1 := subscribed(s.Observable())
+	time.Sleep(10 * time.Millisecond)
+	s.OnNext(1)
+	sub2, values2 := subscribed(s.Observable())
+	time.Sleep(10 * time.Millisecond)
+	s.OnNext(2)
+	s.OnComplete()
+	s.OnNext(3)
+
+	<-sub1.Done()
+	<-sub2.Done()
+	assert.Equal(t, []int{1, 2}, values1())
+	assert.Equal(t, []int{2}, values2())
+
+	// late subscribers get the terminal notification only
+	values, err := s.Observable().Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+}
+
+func TestSubjectError(t *testing.T) {
+	s := rx.NewSubject[int]()
+	s.OnError(errBoom)
+	_, err := s.Observable().Collect(context.Background())
+	assert.ErrorIs(t, err, errBoom)
+}
+
+func TestSubjectDispose(t *testing.T) {
+	s := rx.NewSubject[int]()
+	sub, values := subscribed(s.Observable())
+	time.Sleep(10 * time.Millisecond)
+	s.OnNext(1)
+	sub.Dispose()
+	<-sub.Done()
+	s.OnNext(2)
+	assert.Equal(t, []int{1}, values())
+}
+
+func TestSubjectReentrant(t *testing.T) {
+	s := rx.NewReplaySubject[int]()
+	var lock sync.Mutex
+	var values []int
+	var late rx.Subscription
+	var lateValues func() []int
+	sub := s.Observable().Subscribe(context.Background(), rx.NewObserver(func(v int) {
+		lock.Lock()
+		values = append(values, v)
+		lock.Unlock()
+		// notifying and subscribing from an observer does not deadlock
+		switch v {
+		case 1:
+			s.OnNext(2)
+			late, lateValues = subscribed(s.Observable())
+		case 2:
+			s.OnComplete()
+		}
+	}, nil, nil))
+	time.Sleep(10 * time.Millisecond)
+	s.OnNext(1)
+
+	select {
+	case <-sub.Done():
+	case <-time.After(time.Second):
+		t.Fatal("the re-entrant notifications deadlocked")
+	}
+	<-late.Done()
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, []int{1, 2}, values)
+	assert.Equal(t, []int{1, 2}, lateValues())
+}
+
+func TestSubjectNothingAfterDone(t *testing.T) {
+	for range 20 {
+		s := rx.NewSubject[int]()
+		entered := make(chan struct{}, 1)
+		release := make(chan struct{})
+		blocking := s.Observable().Subscribe(context.Background(), rx.NewObserver(func(int) {
+			entered <- struct{}{}
+			<-release
+		}, nil, nil))
+		var gone atomic.Bool
+		cancelled := s.Observable().Subscribe(context.Background(), rx.NewObserver(func(int) {
+			assert.False(t, gone.Load(), "notified after Done")
+		}, nil, nil))
+		time.Sleep(5 * time.Millisecond)
+
+		// the value is delivered to blocking first, or cancelled already has it
+		emitted := make(chan struct{})
+		go func() {
+			defer close(emitted)
+			s.OnNext(1)
+		}()
+		<-entered
+		cancelled.Dispose()
+		<-cancelled.Done()
+		gone.Store(true)
+		close(release)
+		<-emitted
+		s.OnComplete()
+		<-blocking.Done()
+	}
+}
+
+func TestReplaySubject(t *testing.T) {
+	s := rx.NewReplaySubject[string]()
+	s.OnNext("a")
+	s.OnNext("b")
+	s.OnComplete()
+	values, err := s.Observable().Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, values)
+}
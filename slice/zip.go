@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package slice
+
+import "github.com/mizumoto-cn/fpkit/functional"
+
+// Zip pairs up the elements of two slices.
+// The result is as long as the shorter slice.
+//
+//	Zip([]int{1, 2, 3}, []string{"a", "b"}) // [(1, "a"), (2, "b")]
+func Zip[A, B any](a []A, b []B) []functional.Pair[A, B] {
+	return ZipWith(a, b, functional.PairOf[A, B])
+}
+
+// Zip3 groups the elements of three slices into triples.
+// The result is as long as the shortest slice.
+func Zip3[A, B, C any](a []A, b []B, c []C) []functional.Triple[A, B, C] {
+	n := min(len(a), len(b), len(c))
+	result := make([]functional.Triple[A, B, C], n)
+	for i := range n {
+		result[i] = functional.TripleOf(a[i], b[i], c[i])
+	}
+	return result
+}
+
+// ZipWith combines the elements of two slices with the given function.
+// The result is as long as the shorter slice.
+//
+//	ZipWith([]int{1, 2}, []int{10, 20}, func(x, y int) int { return x + y }) // [11, 22]
+func ZipWith[A, B, C any](a []A, b []B, fn func(A, B) C) []C {
+	n := min(len(a), len(b))
+	result := make([]C, n)
+	for i := range n {
+		result[i] = fn(a[i], b[i])
+	}
+	return result
+}
+
+// ZipLongest pairs up the elements of two slices.
+// The result is as long as the longer slice, the missing elements are padded with Nothing.
+//
+//	ZipLongest([]int{1, 2}, []string{"a"}) // [(Just(1), Just("a")), (Just(2), Nothing)]
+func ZipLongest[A, B any](a []A, b []B) []functional.Pair[functional.Optional[A], functional.Optional[B]] {
+	n := max(len(a), len(b))
+	result := make([]functional.Pair[functional.Optional[A], functional.Optional[B]], n)
+	for i := range n {
+		result[i] = functional.PairOf(at(a, i), at(b, i))
+	}
+	return result
+}
+
+// at returns the element at index i wrapped in an Optional, or Nothing if out of range.
+func at[T any](s []T, i int) functional.Optional[T] {
+	if i < len(s) {
+		return functional.Just(s[i])
+	}
+	return functional.Nothing[T]()
+}
+
+// Unzip splits a slice of pairs into two slices.
+//
+//	Unzip([]Pair[int, string]{PairOf(1, "a"), PairOf(2, "b")}) // [1, 2], ["a", "b"]
+func Unzip[A, B any](pairs []functional.Pair[A, B]) ([]A, []B) {
+	as := make([]A, len(pairs))
+	bs := make([]B, len(pairs))
+	for i, p := range pairs {
+		as[i], bs[i] = p.Unpack()
+	}
+	return as, bs
+}
+
+// Unzip3 splits a slice of triples into three slices.
+func Unzip3[A, B, C any](triples []functional.Triple[A, B, C]) ([]A, []B, []C) {
+	as := make([]A, len(triples))
+	bs := make([]B, len(triples))
+	cs := make([]C, len(triples))
+	for i, t := range triples {
+		as[i], bs[i], cs[i] = t.Unpack()
+	}
+	return as, bs, cs
+}
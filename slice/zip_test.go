@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package slice_test
+
+import (
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/slice"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZip(t *testing.T) {
+	cases := []struct {
+		name string
+		a    []int
+		b    []string
+		want []functional.Pair[int, string]
+	}{
+		{
+			name: "empty",
+			a:    []int{},
+			b:    []string{"a"},
+			want: []functional.Pair[int, string]{},
+		},
+		{
+			name: "same length",
+			a:    []int{1, 2},
+			b:    []string{"a", "b"},
+			want: []functional.Pair[int, string]{functional.PairOf(1, "a"), functional.PairOf(2, "b")},
+		},
+		{
+			name: "truncate to the shorter",
+			a:    []int{1, 2, 3},
+			b:    []string{"a", "b"},
+			want: []functional.Pair[int, string]{functional.PairOf(1, "a"), functional.PairOf(2, "b")},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := slice.Zip(tc.a, tc.b)
+			assert.Equal(t, tc.want, got)
+			as, bs := slice.Unzip(got)
+			assert.Equal(t, tc.a[:len(got)], as)
+			assert.Equal(t, tc.b[:len(got)], bs)
+		})
+	}
+}
+
+func TestZip3(t *testing.T) {
+	got := slice.Zip3([]int{1, 2, 3}, []string{"a", "b"}, []bool{true, false, true})
+	assert.Equal(t, []functional.Triple[int, string, bool]{
+		functional.TripleOf(1, "a", true),
+		functional.TripleOf(2, "b", false),
+	}, got)
+
+	as, bs, cs := slice.Unzip3(got)
+	assert.Equal(t, []int{1, 2}, as)
+	assert.Equal(t, []string{"a", "b"}, bs)
+	assert.Equal(t, []bool{true, false}, cs)
+}
+
+func TestZipWith(t *testing.T) {
+	sum := slice.ZipWith([]int{1, 2, 3}, []int{10, 20}, func(a, b int) int { return a + b })
+	assert.Equal(t, []int{11, 22}, sum)
+}
+
+func TestZipLongest(t *testing.T) {
+	got := slice.ZipLongest([]int{1, 2, 3}, []string{"a"})
+	assert.Len(t, got, 3)
+
+	first, second := got[0].Unpack()
+	assert.Equal(t, 1, first.OrElse(0))
+	assert.Equal(t, "a", second.OrElse("-"))
+
+	for _, p := range got[1:] {
+		assert.True(t, p.First().IsPresent())
+		assert.False(t, p.Second().IsPresent())
+		assert.Equal(t, "-", p.Second().OrElse("-"))
+	}
+
+	got = slice.ZipLongest([]int{}, []string{"a", "b"})
+	assert.Len(t, got, 2)
+	assert.False(t, got[1].First().IsPresent())
+	assert.Equal(t, "b", got[1].Second().Unwrap())
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package std
+
+import (
+	"github.com/mizumoto-cn/fpkit/internal/err"
+	"github.com/mizumoto-cn/fpkit/queue"
+)
+
+// Container is the sequence an adaptor keeps its elements in,
+// like the Container template parameter of the C++ container adaptors.
+// Push appends to the back.
+type Container[T any] interface {
+	Push(T) error
+	Front() (T, error)
+	Back() (T, error)
+	Size() int
+	Clear() error
+}
+
+// FIFOContainer is a Container whose Pop removes the front element, as needed by Queue.
+// queue.BasicQueue, queue.LinkedQueue, queue.Deque and Vector are FIFOContainers.
+type FIFOContainer[T any] interface {
+	Container[T]
+	Pop() (T, error)
+}
+
+// Any queue.Queue is a FIFOContainer, for std.NewQueueOf to take what std.Queue used to embed.
+var _ FIFOContainer[int] = queue.Queue[int](nil)
+
+// LIFOContainer is a Container that can remove its back element, as needed by Stack.
+// queue.Deque and Vector are LIFOContainers.
+type LIFOContainer[T any] interface {
+	Container[T]
+	PopBack() (T, error)
+}
+
+// RandomAccessContainer is a LIFOContainer with indexed access, as needed by PriorityQueue.
+// queue.Deque and Vector are RandomAccessContainers.
+type RandomAccessContainer[T any] interface {
+	LIFOContainer[T]
+	At(int) (T, error)
+	Set(int, T) error
+}
+
+var (
+	_ FIFOContainer[int]         = (*queue.BasicQueue[int])(nil)
+	_ FIFOContainer[int]         = (*queue.LinkedQueue[int])(nil)
+	_ FIFOContainer[int]         = (*queue.Deque[int])(nil)
+	_ RandomAccessContainer[int] = (*queue.Deque[int])(nil)
+	_ FIFOContainer[int]         = (*Vector[int])(nil)
+	_ RandomAccessContainer[int] = (*Vector[int])(nil)
+)
+
+// Vector is a Container on a plain slice, like C++ std::vector.
+// Pop from the front is O(1) but only gives the memory back when the slice grows again,
+// so Vector suits stacks and heaps better than queues.
+// The zero value is an empty Vector ready to use.
+type Vector[T any] struct {
+	items []T
+}
+
+// NewVector creates a new Vector holding the given elements, front first.
+func NewVector[T any](items ...T) *Vector[T] {
+	return &Vector[T]{items: items}
+}
+
+// Push appends an element to the back. It never fails.
+func (v *Vector[T]) Push(t T) error {
+	v.items = append(v.items, t)
+	return nil
+}
+
+// Pop removes and returns the front element.
+func (v *Vector[T]) Pop() (T, error) {
+	var zero T
+	if len(v.items) == 0 {
+		return zero, err.ErrEmptyQueue
+	}
+	t := v.items[0]
+	v.items[0] = zero
+	v.items = v.items[1:]
+	return t, nil
+}
+
+// PopBack removes and returns the back element.
+func (v *Vector[T]) PopBack() (T, error) {
+	var zero T
+	n := len(v.items)
+	if n == 0 {
+		return zero, err.ErrEmptyQueue
+	}
+	t := v.items[n-1]
+	v.items[n-1] = zero
+	v.items = v.items[:n-1]
+	return t, nil
+}
+
+// Front returns the front element.
+func (v *Vector[T]) Front() (T, error) {
+	if len(v.items) == 0 {
+		var zero T
+		return zero, err.ErrEmptyQueue
+	}
+	return v.items[0], nil
+}
+
+// Back returns the back element.
+func (v *Vector[T]) Back() (T, error) {
+	if len(v.items) == 0 {
+		var zero T
+		return zero, err.ErrEmptyQueue
+	}
+	return v.items[len(v.items)-1], nil
+}
+
+// At returns the i-th element from the front.
+func (v *Vector[T]) At(i int) (T, error) {
+	if i < 0 || i >= len(v.items) {
+		var zero T
+		return zero, err.NewIndexOutOfRangeError(i, len(v.items))
+	}
+	return v.items[i], nil
+}
+
+// Set replaces the i-th element from the front.
+func (v *Vector[T]) Set(i int, t T) error {
+	if i < 0 || i >= len(v.items) {
+		return err.NewIndexOutOfRangeError(i, len(v.items))
+	}
+	v.items[i] = t
+	return nil
+}
+
+// Size returns the number of elements.
+func (v *Vector[T]) Size() int {
+	return len(v.items)
+}
+
+// Clear removes all elements.
+func (v *Vector[T]) Clear() error {
+	clear(v.items)
+	v.items = v.items[:0]
+	return nil
+}
+
+// Slice returns the elements from front to back.
+func (v *Vector[T]) Slice() []T {
+	return append([]T(nil), v.items...)
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package std_test
+
+import (
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/internal/err"
+	"github.com/mizumoto-cn/fpkit/std"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVector(t *testing.T) {
+	var v std.Vector[int]
+	assert.Equal(t, 0, v.Size())
+	_, e := v.Pop()
+	assert.ErrorIs(t, e, err.ErrEmptyQueue)
+	_, e = v.PopBack()
+	assert.ErrorIs(t, e, err.ErrEmptyQueue)
+	_, e = v.Front()
+	assert.ErrorIs(t, e, err.ErrEmptyQueue)
+	_, e = v.Back()
+	assert.ErrorIs(t, e, err.ErrEmptyQueue)
+
+	for i := 1; i <= 4; i++ {
+		assert.NoError(t, v.Push(i))
+	}
+	assert.Equal(t, []int{1, 2, 3, 4}, v.Slice())
+	front, _ := v.Front()
+	back, _ := v.Back()
+	assert.Equal(t, 1, front)
+	assert.Equal(t, 4, back)
+
+	assert.NoError(t, v.Set(1, 20))
+	x, e := v.At(1)
+	assert.NoError(t, e)
+	assert.Equal(t, 20, x)
+	_, e = v.At(4)
+	assert.Equal(t, err.NewIndexOutOfRangeError(4, 4), e)
+	assert.Equal(t, err.NewIndexOutOfRangeError(-1, 4), v.Set(-1, 0))
+
+	x, _ = v.Pop()
+	assert.Equal(t, 1, x)
+	x, _ = v.PopBack()
+	assert.Equal(t, 4, x)
+	assert.Equal(t, []int{20, 3}, v.Slice())
+
+	assert.NoError(t, v.Clear())
+	assert.Equal(t, 0, v.Size())
+	assert.Nil(t, v.Slice())
+}
+
+func TestNewVector(t *testing.T) {
+	v := std.NewVector(1, 2, 3)
+	assert.Equal(t, 3, v.Size())
+	x, _ := v.Back()
+	assert.Equal(t, 3, x)
+}
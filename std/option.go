@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package std
+
+// containerKind is the container selected by an option.
+type containerKind int
+
+const (
+	defaultKind containerKind = iota
+	dequeKind
+	vectorKind
+	basicQueueKind
+	linkedQueueKind
+)
+
+// config is what the options of an adaptor constructor set.
+type config struct {
+	kind containerKind
+	cap  int
+}
+
+// QueueOption selects the container of a Queue.
+type QueueOption interface {
+	applyQueue(*config)
+}
+
+// StackOption selects the container of a Stack.
+type StackOption interface {
+	applyStack(*config)
+}
+
+// PriorityQueueOption selects the container of a PriorityQueue.
+type PriorityQueueOption interface {
+	applyPriorityQueue(*config)
+}
+
+// SequenceOption selects a container that every adaptor can use.
+type SequenceOption struct {
+	kind containerKind
+}
+
+func (o SequenceOption) applyQueue(c *config)         { c.kind = o.kind }
+func (o SequenceOption) applyStack(c *config)         { c.kind = o.kind }
+func (o SequenceOption) applyPriorityQueue(c *config) { c.kind = o.kind }
+
+// FIFOOption selects a container that can only pop from the front, so only a Queue can use it.
+// Passing one to NewStack or NewPriorityQueue does not compile.
+type FIFOOption struct {
+	kind containerKind
+	cap  int
+}
+
+func (o FIFOOption) applyQueue(c *config) { c.kind, c.cap = o.kind, o.cap }
+
+// WithDeque keeps the elements in a queue.Deque. It is the default of Queue and Stack.
+func WithDeque() SequenceOption {
+	return SequenceOption{kind: dequeKind}
+}
+
+// WithSlice keeps the elements in a Vector. It is the default of PriorityQueue.
+func WithSlice() SequenceOption {
+	return SequenceOption{kind: vectorKind}
+}
+
+// WithBasicQueue keeps the elements in a queue.BasicQueue of the given capacity,
+// so Push fails once the queue holds cap elements.
+func WithBasicQueue(cap int) FIFOOption {
+	return FIFOOption{kind: basicQueueKind, cap: cap}
+}
+
+// WithLinkedQueue keeps the elements in a queue.LinkedQueue.
+func WithLinkedQueue() FIFOOption {
+	return FIFOOption{kind: linkedQueueKind}
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package std
+
+import (
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/queue"
+)
+
+// PriorityQueue is a binary heap adaptor over a RandomAccessContainer, like C++ std::priority_queue.
+// Unlike C++, the top is the element that comes first by cmp, so functional.Less gives a min-heap.
+// The container is a Vector unless an option selects another one.
+// PriorityQueue is not thread-safe.
+//
+//	pq := std.NewPriorityQueue[int](functional.Less[int])
+//	_ = pq.Push(3)
+//	_ = pq.Push(1)
+//	v, _ := pq.Pop() // 1
+type PriorityQueue[T any] struct {
+	c   RandomAccessContainer[T]
+	cmp functional.ComparatorAny[T]
+}
+
+// NewPriorityQueue creates a new empty PriorityQueue ordered by cmp, on the container selected by opts.
+func NewPriorityQueue[T any](cmp functional.ComparatorAny[T], opts ...PriorityQueueOption) *PriorityQueue[T] {
+	var cfg config
+	for _, opt := range opts {
+		opt.applyPriorityQueue(&cfg)
+	}
+	var c RandomAccessContainer[T]
+	switch cfg.kind {
+	case dequeKind:
+		c = queue.NewDeque[T]()
+	default:
+		c = NewVector[T]()
+	}
+	return &PriorityQueue[T]{c: c, cmp: cmp}
+}
+
+// NewPriorityQueueOf creates a PriorityQueue ordered by cmp on the given container.
+// The elements it already holds are rearranged into a heap in O(n).
+func NewPriorityQueueOf[T any](cmp functional.ComparatorAny[T], c RandomAccessContainer[T]) *PriorityQueue[T] {
+	pq := &PriorityQueue[T]{c: c, cmp: cmp}
+	for i := c.Size()/2 - 1; i >= 0; i-- {
+		pq.down(i, c.Size())
+	}
+	return pq
+}
+
+// Container returns the underlying container, in heap order.
+func (pq *PriorityQueue[T]) Container() RandomAccessContainer[T] {
+	return pq.c
+}
+
+// less reports whether the i-th element comes before the j-th one.
+func (pq *PriorityQueue[T]) less(i, j int) bool {
+	a, _ := pq.c.At(i)
+	b, _ := pq.c.At(j)
+	return pq.cmp(a, b)
+}
+
+// swap swaps the i-th and j-th elements.
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	a, _ := pq.c.At(i)
+	b, _ := pq.c.At(j)
+	_ = pq.c.Set(i, b)
+	_ = pq.c.Set(j, a)
+}
+
+// up moves the i-th element towards the root until its parent comes before it.
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		p := (i - 1) / 2
+		if !pq.less(i, p) {
+			return
+		}
+		pq.swap(i, p)
+		i = p
+	}
+}
+
+// down moves the i-th element towards the leaves, among the first n, until it comes before its children.
+func (pq *PriorityQueue[T]) down(i, n int) {
+	for {
+		m := i
+		if l := 2*i + 1; l < n && pq.less(l, m) {
+			m = l
+		}
+		if r := 2*i + 2; r < n && pq.less(r, m) {
+			m = r
+		}
+		if m == i {
+			return
+		}
+		pq.swap(i, m)
+		i = m
+	}
+}
+
+// Push adds an element, in O(log n).
+func (pq *PriorityQueue[T]) Push(t T) error {
+	if e := pq.c.Push(t); e != nil {
+		return e
+	}
+	pq.up(pq.c.Size() - 1)
+	return nil
+}
+
+// Emplace adds the element built by fn, in O(log n).
+func (pq *PriorityQueue[T]) Emplace(fn func() T) error {
+	return pq.Push(fn())
+}
+
+// Pop removes and returns the top element, in O(log n).
+func (pq *PriorityQueue[T]) Pop() (T, error) {
+	n := pq.c.Size()
+	if n <= 1 {
+		return pq.c.PopBack()
+	}
+	pq.swap(0, n-1)
+	t, e := pq.c.PopBack()
+	if e != nil {
+		return t, e
+	}
+	pq.down(0, n-1)
+	return t, nil
+}
+
+// Top returns the top element, the first one by cmp.
+func (pq *PriorityQueue[T]) Top() (T, error) {
+	return pq.c.Front()
+}
+
+// Empty returns true if the priority queue holds no elements.
+func (pq *PriorityQueue[T]) Empty() bool {
+	return pq.c.Size() == 0
+}
+
+// Size returns the number of elements.
+func (pq *PriorityQueue[T]) Size() int {
+	return pq.c.Size()
+}
+
+// Swap swaps the contents, containers and comparators of two priority queues.
+func (pq *PriorityQueue[T]) Swap(other *PriorityQueue[T]) {
+	*pq, *other = *other, *pq
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package std_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/internal/err"
+	"github.com/mizumoto-cn/fpkit/queue"
+	"github.com/mizumoto-cn/fpkit/std"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	options := map[string][]std.PriorityQueueOption{
+		"Default": nil,
+		"Slice":   {std.WithSlice()},
+		"Deque":   {std.WithDeque()},
+	}
+	for name, opts := range options {
+		t.Run(name, func(t *testing.T) {
+			pq := std.NewPriorityQueue[int](functional.Less[int], opts...)
+			assert.True(t, pq.Empty())
+			_, e := pq.Pop()
+			assert.ErrorIs(t, e, err.ErrEmptyQueue)
+			_, e = pq.Top()
+			assert.ErrorIs(t, e, err.ErrEmptyQueue)
+
+			in := rand.Perm(200)
+			for _, v := range in {
+				assert.NoError(t, pq.Push(v))
+			}
+			assert.NoError(t, pq.Emplace(func() int { return -1 }))
+			assert.Equal(t, len(in)+1, pq.Size())
+			top, _ := pq.Top()
+			assert.Equal(t, -1, top)
+
+			for want := -1; want < len(in); want++ {
+				v, e := pq.Pop()
+				assert.NoError(t, e)
+				assert.Equal(t, want, v)
+			}
+			assert.True(t, pq.Empty())
+		})
+	}
+}
+
+func TestPriorityQueueInterleaved(t *testing.T) {
+	pq := std.NewPriorityQueue[int](functional.Greater[int])
+	var model []int
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		if len(model) == 0 || r.Intn(3) > 0 {
+			v := r.Intn(100)
+			_ = pq.Push(v)
+			model = append(model, v)
+			continue
+		}
+		slices.Sort(model)
+		v, e := pq.Pop()
+		assert.NoError(t, e)
+		assert.Equal(t, model[len(model)-1], v)
+		model = model[:len(model)-1]
+		assert.Equal(t, len(model), pq.Size())
+	}
+}
+
+func TestPriorityQueueOf(t *testing.T) {
+	d := queue.NewDeque[int]()
+	for _, v := range []int{5, 3, 8, 1, 9, 2} {
+		d.PushBack(v)
+	}
+	pq := std.NewPriorityQueueOf[int](functional.Less[int], d)
+	var out []int
+	for !pq.Empty() {
+		v, _ := pq.Pop()
+		out = append(out, v)
+	}
+	assert.Equal(t, []int{1, 2, 3, 5, 8, 9}, out)
+}
+
+func TestPriorityQueueSwap(t *testing.T) {
+	a := std.NewPriorityQueue[int](functional.Less[int])
+	b := std.NewPriorityQueue[int](functional.Greater[int], std.WithDeque())
+	for _, v := range []int{1, 2, 3} {
+		_ = a.Push(v)
+		_ = b.Push(v)
+	}
+	_ = b.Push(4)
+
+	a.Swap(b)
+	assert.Equal(t, 4, a.Size())
+	assert.Equal(t, 3, b.Size())
+	v, _ := a.Top()
+	assert.Equal(t, 4, v)
+	v, _ = b.Top()
+	assert.Equal(t, 1, v)
+	assert.IsType(t, &queue.Deque[int]{}, a.Container())
+}
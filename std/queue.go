@@ -22,7 +22,89 @@ import (
 	"github.com/mizumoto-cn/fpkit/queue"
 )
 
-// std.Queue is a generic struct for a standard FIFO queue with a given capacity.
+// Queue is a FIFO adaptor over a FIFOContainer, like C++ std::queue.
+// It pushes to the back of the container and pops from the front.
+// The container is a queue.Deque unless an option selects another one.
+// Queue is only as thread-safe as its container, so not thread-safe by default.
+//
+//	q := std.NewQueue[int](std.WithLinkedQueue())
+//	_ = q.Push(1)
+//	v, _ := q.Pop() // 1
+//
+// Queue used to embed a queue.Queue. Code building one as std.Queue[T]{Queue: q}
+// now calls std.NewQueueOf(q), and reaches Cap and Clear through Container.
 type Queue[T any] struct {
-	queue.Queue[T]
+	c FIFOContainer[T]
+}
+
+// NewQueue creates a new empty Queue on the container selected by opts.
+func NewQueue[T any](opts ...QueueOption) *Queue[T] {
+	var cfg config
+	for _, opt := range opts {
+		opt.applyQueue(&cfg)
+	}
+	var c FIFOContainer[T]
+	switch cfg.kind {
+	case vectorKind:
+		c = NewVector[T]()
+	case basicQueueKind:
+		c = queue.NewBasicQueue[T](cfg.cap)
+	case linkedQueueKind:
+		c = queue.NewLinkedQueue[T]()
+	default:
+		c = queue.NewDeque[T]()
+	}
+	return &Queue[T]{c: c}
+}
+
+// NewQueueOf creates a Queue on the given container, keeping the elements it already holds.
+// Any queue.Queue is a FIFOContainer.
+func NewQueueOf[T any](c FIFOContainer[T]) *Queue[T] {
+	return &Queue[T]{c: c}
+}
+
+// Container returns the underlying container.
+func (q *Queue[T]) Container() FIFOContainer[T] {
+	return q.c
+}
+
+// Push adds an element to the back of the queue.
+// It fails only if the container is bounded and full.
+func (q *Queue[T]) Push(t T) error {
+	return q.c.Push(t)
+}
+
+// Emplace adds the element built by fn to the back of the queue.
+func (q *Queue[T]) Emplace(fn func() T) error {
+	return q.c.Push(fn())
+}
+
+// Pop removes and returns the front element.
+func (q *Queue[T]) Pop() (T, error) {
+	return q.c.Pop()
+}
+
+// Front returns the front element, the next one to pop.
+func (q *Queue[T]) Front() (T, error) {
+	return q.c.Front()
+}
+
+// Back returns the back element, the last one pushed.
+func (q *Queue[T]) Back() (T, error) {
+	return q.c.Back()
+}
+
+// Empty returns true if the queue holds no elements.
+func (q *Queue[T]) Empty() bool {
+	return q.c.Size() == 0
+}
+
+// Size returns the number of elements.
+func (q *Queue[T]) Size() int {
+	return q.c.Size()
+}
+
+// Swap swaps the contents, and so the containers, of two queues.
+func (q *Queue[T]) Swap(other *Queue[T]) {
+	q.c, other.c = other.c, q.c
 }
@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package std_test
+
+import (
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/internal/err"
+	"github.com/mizumoto-cn/fpkit/queue"
+	"github.com/mizumoto-cn/fpkit/std"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue(t *testing.T) {
+	options := map[string][]std.QueueOption{
+		"Default":     nil,
+		"Deque":       {std.WithDeque()},
+		"Slice":       {std.WithSlice()},
+		"BasicQueue":  {std.WithBasicQueue(8)},
+		"LinkedQueue": {std.WithLinkedQueue()},
+	}
+	for name, opts := range options {
+		t.Run(name, func(t *testing.T) {
+			q := std.NewQueue[int](opts...)
+			assert.True(t, q.Empty())
+			_, e := q.Pop()
+			assert.Error(t, e)
+
+			assert.NoError(t, q.Push(1))
+			assert.NoError(t, q.Push(2))
+			assert.NoError(t, q.Emplace(func() int { return 3 }))
+			assert.Equal(t, 3, q.Size())
+			front, _ := q.Front()
+			back, _ := q.Back()
+			assert.Equal(t, 1, front)
+			assert.Equal(t, 3, back)
+
+			for want := 1; want <= 3; want++ {
+				v, e := q.Pop()
+				assert.NoError(t, e)
+				assert.Equal(t, want, v)
+			}
+			assert.True(t, q.Empty())
+		})
+	}
+}
+
+func TestQueueContainer(t *testing.T) {
+	assert.IsType(t, &queue.Deque[int]{}, std.NewQueue[int]().Container())
+	assert.IsType(t, &std.Vector[int]{}, std.NewQueue[int](std.WithSlice()).Container())
+	assert.IsType(t, &queue.BasicQueue[int]{}, std.NewQueue[int](std.WithBasicQueue(1)).Container())
+	assert.IsType(t, &queue.LinkedQueue[int]{}, std.NewQueue[int](std.WithLinkedQueue()).Container())
+	// the last option wins
+	assert.IsType(t, &queue.Deque[int]{}, std.NewQueue[int](std.WithLinkedQueue(), std.WithDeque()).Container())
+}
+
+func TestQueueBounded(t *testing.T) {
+	q := std.NewQueue[int](std.WithBasicQueue(1))
+	assert.NoError(t, q.Push(1))
+	assert.Equal(t, err.NewQueueFullError(1, 1), q.Push(2))
+	assert.Error(t, q.Emplace(func() int { return 2 }))
+	assert.Equal(t, 1, q.Size())
+}
+
+func TestQueueOf(t *testing.T) {
+	q := std.NewQueueOf[int](std.NewVector(1, 2))
+	v, _ := q.Pop()
+	assert.Equal(t, 1, v)
+	assert.Equal(t, 1, q.Size())
+
+	// the migration from std.Queue[int]{Queue: bq}
+	bq := queue.NewBasicQueue[int](4)
+	_ = bq.Push(3)
+	q = std.NewQueueOf[int](bq)
+	_ = q.Push(4)
+	v, _ = q.Front()
+	assert.Equal(t, 3, v)
+	assert.Equal(t, 4, q.Container().(queue.Queue[int]).Cap())
+}
+
+func TestQueueSwap(t *testing.T) {
+	a := std.NewQueue[int](std.WithLinkedQueue())
+	b := std.NewQueue[int]()
+	_ = a.Push(1)
+	_ = b.Push(2)
+	_ = b.Push(3)
+
+	a.Swap(b)
+	assert.Equal(t, 2, a.Size())
+	assert.Equal(t, 1, b.Size())
+	assert.IsType(t, &queue.Deque[int]{}, a.Container())
+	assert.IsType(t, &queue.LinkedQueue[int]{}, b.Container())
+	v, _ := a.Front()
+	assert.Equal(t, 2, v)
+	v, _ = b.Front()
+	assert.Equal(t, 1, v)
+}
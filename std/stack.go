@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package std
+
+import (
+	"github.com/mizumoto-cn/fpkit/queue"
+)
+
+// Stack is a LIFO adaptor over a LIFOContainer, like C++ std::stack.
+// It pushes to and pops from the back of the container.
+// The container is a queue.Deque unless an option selects another one.
+// Stack is not thread-safe.
+//
+//	s := std.NewStack[int](std.WithSlice())
+//	_ = s.Push(1)
+//	_ = s.Push(2)
+//	v, _ := s.Pop() // 2
+type Stack[T any] struct {
+	c LIFOContainer[T]
+}
+
+// NewStack creates a new empty Stack on the container selected by opts.
+func NewStack[T any](opts ...StackOption) *Stack[T] {
+	var cfg config
+	for _, opt := range opts {
+		opt.applyStack(&cfg)
+	}
+	var c LIFOContainer[T]
+	switch cfg.kind {
+	case vectorKind:
+		c = NewVector[T]()
+	default:
+		c = queue.NewDeque[T]()
+	}
+	return &Stack[T]{c: c}
+}
+
+// NewStackOf creates a Stack on the given container, keeping the elements it already holds.
+// The back of the container is the top of the stack.
+func NewStackOf[T any](c LIFOContainer[T]) *Stack[T] {
+	return &Stack[T]{c: c}
+}
+
+// Container returns the underlying container.
+func (s *Stack[T]) Container() LIFOContainer[T] {
+	return s.c
+}
+
+// Push adds an element on top of the stack.
+func (s *Stack[T]) Push(t T) error {
+	return s.c.Push(t)
+}
+
+// Emplace adds the element built by fn on top of the stack.
+func (s *Stack[T]) Emplace(fn func() T) error {
+	return s.c.Push(fn())
+}
+
+// Pop removes and returns the top element.
+func (s *Stack[T]) Pop() (T, error) {
+	return s.c.PopBack()
+}
+
+// Top returns the top element, the last one pushed.
+func (s *Stack[T]) Top() (T, error) {
+	return s.c.Back()
+}
+
+// Empty returns true if the stack holds no elements.
+func (s *Stack[T]) Empty() bool {
+	return s.c.Size() == 0
+}
+
+// Size returns the number of elements.
+func (s *Stack[T]) Size() int {
+	return s.c.Size()
+}
+
+// Swap swaps the contents, and so the containers, of two stacks.
+func (s *Stack[T]) Swap(other *Stack[T]) {
+	s.c, other.c = other.c, s.c
+}
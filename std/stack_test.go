@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package std_test
+
+import (
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/internal/err"
+	"github.com/mizumoto-cn/fpkit/queue"
+	"github.com/mizumoto-cn/fpkit/std"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStack(t *testing.T) {
+	options := map[string][]std.StackOption{
+		"Default": nil,
+		"Deque":   {std.WithDeque()},
+		"Slice":   {std.WithSlice()},
+	}
+	for name, opts := range options {
+		t.Run(name, func(t *testing.T) {
+			s := std.NewStack[int](opts...)
+			assert.True(t, s.Empty())
+			_, e := s.Pop()
+			assert.ErrorIs(t, e, err.ErrEmptyQueue)
+			_, e = s.Top()
+			assert.ErrorIs(t, e, err.ErrEmptyQueue)
+
+			assert.NoError(t, s.Push(1))
+			assert.NoError(t, s.Push(2))
+			assert.NoError(t, s.Emplace(func() int { return 3 }))
+			assert.Equal(t, 3, s.Size())
+			top, _ := s.Top()
+			assert.Equal(t, 3, top)
+
+			for want := 3; want >= 1; want-- {
+				v, e := s.Pop()
+				assert.NoError(t, e)
+				assert.Equal(t, want, v)
+			}
+			assert.True(t, s.Empty())
+		})
+	}
+}
+
+func TestStackOf(t *testing.T) {
+	s := std.NewStackOf[int](std.NewVector(1, 2))
+	v, _ := s.Pop()
+	assert.Equal(t, 2, v)
+	assert.IsType(t, &std.Vector[int]{}, s.Container())
+}
+
+func TestStackSwap(t *testing.T) {
+	a := std.NewStack[int](std.WithSlice())
+	b := std.NewStack[int]()
+	_ = a.Push(1)
+	_ = b.Push(2)
+	_ = b.Push(3)
+
+	a.Swap(b)
+	assert.Equal(t, 2, a.Size())
+	assert.Equal(t, 1, b.Size())
+	assert.IsType(t, &queue.Deque[int]{}, a.Container())
+	v, _ := a.Top()
+	assert.Equal(t, 3, v)
+	v, _ = b.Top()
+	assert.Equal(t, 1, v)
+}
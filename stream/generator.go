@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package stream
+
+import "github.com/mizumoto-cn/fpkit/functional"
+
+// Range returns a Stream of numbers from start (inclusive) to end (exclusive) by step.
+// A negative step counts down, a zero step gives an empty Stream.
+// Floating point values are computed as start + i*step, so that errors do not accumulate,
+// and integer ranges stop before overflowing.
+//
+//	Range(0, 10, 3).Collect()        // [0, 3, 6, 9]
+//	Range(1.0, 0.0, -0.25).Collect() // [1, 0.75, 0.5, 0.25]
+func Range[T functional.Real](start, end, step T) Stream[T] {
+	var half T = 1
+	half /= 2
+	isFloat := half != 0
+
+	var zero T
+	ascending := step > zero
+	if step == zero {
+		return Empty[T]()
+	}
+	i, v, done := 0, start, false
+	return New(func() (T, bool) {
+		if done || (ascending && v >= end) || (!ascending && v <= end) {
+			done = true
+			return zero, false
+		}
+		cur := v
+		i++
+		if isFloat {
+			v = start + T(i)*step
+		} else {
+			v += step
+			// wrapped around
+			if (ascending && v < cur) || (!ascending && v > cur) {
+				done = true
+			}
+		}
+		return cur, true
+	})
+}
+
+// Iterate returns the infinite Stream seed, fn(seed), fn(fn(seed)), ...
+func Iterate[T any](seed T, fn func(T) T) Stream[T] {
+	v, started := seed, false
+	return New(func() (T, bool) {
+		if started {
+			v = fn(v)
+		}
+		started = true
+		return v, true
+	})
+}
+
+// Repeat returns the infinite Stream v, v, v, ...
+func Repeat[T any](v T) Stream[T] {
+	return New(func() (T, bool) {
+		return v, true
+	})
+}
+
+// RepeatN returns a Stream of n times v.
+func RepeatN[T any](v T, n int) Stream[T] {
+	return Repeat(v).Take(n)
+}
+
+// Cycle returns the infinite Stream repeating the elements of the slice.
+// Cycling an empty slice gives an empty Stream.
+//
+//	Cycle([]int{1, 2}).Take(5).Collect() // [1, 2, 1, 2, 1]
+func Cycle[T any](s []T) Stream[T] {
+	if len(s) == 0 {
+		return Empty[T]()
+	}
+	i := 0
+	return New(func() (T, bool) {
+		v := s[i]
+		i = (i + 1) % len(s)
+		return v, true
+	})
+}
+
+// Unfold builds a Stream from a seed.
+// The function returns the next value and the next seed, or Nothing to end the Stream.
+//
+//	fib := Unfold(PairOf(0, 1), func(s Pair[int, int]) Optional[Pair[int, Pair[int, int]]] {
+//		a, b := s.Unpack()
+//		return Just(PairOf(a, PairOf(b, a+b)))
+//	})
+//	fib.Take(6).Collect() // [0, 1, 1, 2, 3, 5]
+func Unfold[T, S any](seed S, fn func(S) functional.Optional[functional.Pair[T, S]]) Stream[T] {
+	done := false
+	return New(func() (T, bool) {
+		var zero T
+		if done {
+			return zero, false
+		}
+		next := fn(seed)
+		if !next.IsPresent() {
+			done = true
+			return zero, false
+		}
+		var v T
+		v, seed = next.Unwrap().Unpack()
+		return v, true
+	})
+}
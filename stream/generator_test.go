@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package stream_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/stream"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRange(t *testing.T) {
+	assert.Equal(t, []int{0, 3, 6, 9}, stream.Range(0, 10, 3).Collect())
+	assert.Equal(t, []int{5, 3, 1}, stream.Range(5, 0, -2).Collect())
+	assert.Nil(t, stream.Range(0, 10, 0).Collect())
+	assert.Nil(t, stream.Range(10, 0, 1).Collect())
+	assert.Equal(t, []float64{1, 0.75, 0.5, 0.25}, stream.Range(1.0, 0.0, -0.25).Collect())
+
+	// integer ranges stop before wrapping around
+	assert.Equal(t, []uint8{250}, stream.Range[uint8](250, 255, 10).Collect())
+	assert.Equal(t, []int8{120, 125}, stream.Range[int8](120, math.MaxInt8, 5).Collect())
+	assert.Equal(t, []int8{-120, -125}, stream.Range[int8](-120, math.MinInt8, -5).Collect())
+}
+
+func TestRangeFloatAccumulation(t *testing.T) {
+	// adding 0.1 a thousand times drifts, start + i*step does not
+	got := stream.Range(0.0, 100.0, 0.1).Collect()
+	assert.Len(t, got, 1000)
+	assert.Equal(t, 0.1*999, got[999])
+
+	got32 := stream.Range[float32](0, 1, 0.1).Collect()
+	assert.Len(t, got32, 10)
+}
+
+func TestIterate(t *testing.T) {
+	powers := stream.Iterate(1, func(x int) int { return x * 2 })
+	assert.Equal(t, []int{1, 2, 4, 8, 16}, powers.Take(5).Collect())
+
+	small := stream.Iterate(1, func(x int) int { return x * 3 }).
+		TakeWhile(func(x int) bool { return x < 100 })
+	assert.Equal(t, []int{1, 3, 9, 27, 81}, small.Collect())
+}
+
+func TestRepeat(t *testing.T) {
+	assert.Equal(t, []string{"a", "a", "a"}, stream.Repeat("a").Take(3).Collect())
+	assert.Equal(t, []int{7, 7}, stream.RepeatN(7, 2).Collect())
+	assert.Nil(t, stream.RepeatN(7, 0).Collect())
+}
+
+func TestCycle(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3, 1, 2}, stream.Cycle([]int{1, 2, 3}).Take(5).Collect())
+	assert.Nil(t, stream.Cycle([]int{}).Take(5).Collect())
+}
+
+func TestUnfold(t *testing.T) {
+	type state = functional.Pair[int, int]
+	fib := stream.Unfold(functional.PairOf(0, 1), func(s state) functional.Optional[functional.Pair[int, state]] {
+		a, b := s.Unpack()
+		return functional.Just(functional.PairOf(a, functional.PairOf(b, a+b)))
+	})
+	assert.Equal(t, []int{0, 1, 1, 2, 3, 5, 8}, fib.Take(7).Collect())
+
+	countdown := stream.Unfold(3, func(n int) functional.Optional[functional.Pair[string, int]] {
+		if n == 0 {
+			return functional.Nothing[functional.Pair[string, int]]()
+		}
+		return functional.Just(functional.PairOf(string(rune('0'+n)), n-1))
+	})
+	assert.Equal(t, []string{"3", "2", "1"}, countdown.Collect())
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package stream
+
+import "github.com/mizumoto-cn/fpkit/slice"
+
+// Stream is a lazy, possibly infinite, sequence of values computed on demand.
+// A Stream is single-use: pulling from it, or from any Stream derived from it, consumes it.
+//
+//	Map(Iterate(1, func(x int) int { return x * 2 }), strconv.Itoa).Take(4).Collect() // ["1", "2", "4", "8"]
+type Stream[T any] struct {
+	pull func() (T, bool)
+}
+
+// New creates a Stream from a pull function,
+// which returns the next value and true, or false when the stream is exhausted.
+func New[T any](pull func() (T, bool)) Stream[T] {
+	return Stream[T]{pull: pull}
+}
+
+// Empty returns a Stream without any value.
+func Empty[T any]() Stream[T] {
+	return New(func() (T, bool) {
+		var zero T
+		return zero, false
+	})
+}
+
+// Of returns a Stream of the given values.
+func Of[T any](v ...T) Stream[T] {
+	return FromSlice(v)
+}
+
+// FromSlice returns a Stream over the elements of the slice.
+func FromSlice[T any](s []T) Stream[T] {
+	i := 0
+	return New(func() (T, bool) {
+		if i >= len(s) {
+			var zero T
+			return zero, false
+		}
+		i++
+		return s[i-1], true
+	})
+}
+
+// FromIterator returns a Stream over the remaining elements of the iterator.
+func FromIterator[T any](it *slice.Iterator[T]) Stream[T] {
+	return New(func() (T, bool) {
+		if !it.HasNext() {
+			var zero T
+			return zero, false
+		}
+		return it.Next(), true
+	})
+}
+
+// Next returns the next value of the Stream and true, or false when it is exhausted.
+func (s Stream[T]) Next() (T, bool) {
+	return s.pull()
+}
+
+// Take returns a Stream of at most the first n values.
+// It is the usual way to bound an infinite Stream.
+func (s Stream[T]) Take(n int) Stream[T] {
+	return New(func() (T, bool) {
+		if n <= 0 {
+			var zero T
+			return zero, false
+		}
+		n--
+		return s.pull()
+	})
+}
+
+// TakeWhile returns a Stream of the leading values satisfying the predicate.
+func (s Stream[T]) TakeWhile(p func(T) bool) Stream[T] {
+	done := false
+	return New(func() (T, bool) {
+		var zero T
+		if done {
+			return zero, false
+		}
+		v, ok := s.pull()
+		if !ok || !p(v) {
+			done = true
+			return zero, false
+		}
+		return v, true
+	})
+}
+
+// Drop returns a Stream without the first n values.
+func (s Stream[T]) Drop(n int) Stream[T] {
+	return New(func() (T, bool) {
+		for ; n > 0; n-- {
+			if _, ok := s.pull(); !ok {
+				var zero T
+				return zero, false
+			}
+		}
+		return s.pull()
+	})
+}
+
+// Filter returns a Stream of the values satisfying the predicate.
+func (s Stream[T]) Filter(p func(T) bool) Stream[T] {
+	return New(func() (T, bool) {
+		for {
+			v, ok := s.pull()
+			if !ok || p(v) {
+				return v, ok
+			}
+		}
+	})
+}
+
+// Map returns a Stream of the results of applying the function to each value.
+func Map[T, U any](s Stream[T], fn func(T) U) Stream[U] {
+	return New(func() (U, bool) {
+		v, ok := s.pull()
+		if !ok {
+			var zero U
+			return zero, false
+		}
+		return fn(v), true
+	})
+}
+
+// ForEach calls the function on every value of the Stream.
+// It never returns on an infinite Stream.
+func (s Stream[T]) ForEach(fn func(T)) {
+	for v, ok := s.pull(); ok; v, ok = s.pull() {
+		fn(v)
+	}
+}
+
+// Collect returns all the values of the Stream in a slice.
+// It never returns on an infinite Stream, bound it with Take or TakeWhile first.
+func (s Stream[T]) Collect() []T {
+	var result []T
+	s.ForEach(func(v T) {
+		result = append(result, v)
+	})
+	return result
+}
+
+// Iterator collects the Stream into a slice.Iterator.
+// It never returns on an infinite Stream, bound it with Take or TakeWhile first.
+func (s Stream[T]) Iterator() *slice.Iterator[T] {
+	return slice.NewIterator(s.Collect())
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package stream_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/slice"
+	"github.com/mizumoto-cn/fpkit/stream"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromSlice(t *testing.T) {
+	s := stream.FromSlice([]int{1, 2, 3})
+	v, ok := s.Next()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, []int{2, 3}, s.Collect())
+	_, ok = s.Next()
+	assert.False(t, ok)
+
+	assert.Nil(t, stream.Empty[int]().Collect())
+	assert.Equal(t, []string{"a", "b"}, stream.Of("a", "b").Collect())
+}
+
+func TestIterator(t *testing.T) {
+	it := slice.NewIterator([]int{1, 2, 3, 4})
+	it.Next()
+	assert.Equal(t, []int{2, 3, 4}, stream.FromIterator(it).Collect())
+	assert.False(t, it.HasNext())
+
+	back := stream.Of(5, 6).Iterator()
+	assert.True(t, back.HasNext())
+	assert.Equal(t, 5, back.Next())
+	assert.Equal(t, 6, back.Next())
+	assert.False(t, back.HasNext())
+}
+
+func TestOperators(t *testing.T) {
+	cases := []struct {
+		name string
+		s    func() stream.Stream[int]
+		want []int
+	}{
+		{
+			name: "take",
+			s:    func() stream.Stream[int] { return stream.Of(1, 2, 3).Take(2) },
+			want: []int{1, 2},
+		},
+		{
+			name: "take more than available",
+			s:    func() stream.Stream[int] { return stream.Of(1, 2, 3).Take(5) },
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "take while",
+			s: func() stream.Stream[int] {
+				return stream.Of(1, 2, 3, 1).TakeWhile(func(x int) bool { return x < 3 })
+			},
+			want: []int{1, 2},
+		},
+		{
+			name: "drop",
+			s:    func() stream.Stream[int] { return stream.Of(1, 2, 3).Drop(2) },
+			want: []int{3},
+		},
+		{
+			name: "drop everything",
+			s:    func() stream.Stream[int] { return stream.Of(1, 2, 3).Drop(5) },
+			want: nil,
+		},
+		{
+			name: "filter",
+			s: func() stream.Stream[int] {
+				return stream.Of(1, 2, 3, 4).Filter(func(x int) bool { return x%2 == 0 })
+			},
+			want: []int{2, 4},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.s().Collect())
+		})
+	}
+}
+
+func TestMap(t *testing.T) {
+	s := stream.Map(stream.Of(1, 2, 3), strconv.Itoa)
+	assert.Equal(t, []string{"1", "2", "3"}, s.Collect())
+
+	sum := 0
+	stream.Of(1, 2, 3).ForEach(func(x int) { sum += x })
+	assert.Equal(t, 6, sum)
+}
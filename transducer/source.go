@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package transducer
+
+import (
+	"context"
+
+	"github.com/mizumoto-cn/fpkit/queue"
+)
+
+// reducing returns the sink reducing the values into *acc with fn.
+func reducing[A, T any](acc *A, fn func(A, T) A) Sink[T] {
+	return NewSink(func(v T) Step {
+		*acc = fn(*acc, v)
+		return Continue
+	}, nil)
+}
+
+// Transduce reduces the elements of the slice transformed by xf, from init with the reducer,
+// with the same reducer as functional.Foldl.
+//
+//	Transduce(Map(double), func(acc, x int) int { return acc + x }, 0, []int{1, 2, 3}) // 12
+func Transduce[T, U, A any](xf Transducer[T, U], reducer func(A, U) A, init A, s []T) A {
+	sink := xf(reducing(&init, reducer))
+	for _, v := range s {
+		if !sink.Push(v).more() {
+			break
+		}
+	}
+	sink.Complete()
+	return init
+}
+
+// Into appends the elements of the slice transformed by xf to dst.
+//
+//	Into(nil, Compose(Filter(isEven), Map(strconv.Itoa)), []int{1, 2, 3, 4}) // ["2", "4"]
+func Into[T, U any](dst []U, xf Transducer[T, U], s []T) []U {
+	return Transduce(xf, func(acc []U, v U) []U { return append(acc, v) }, dst, s)
+}
+
+// TransduceQueue drains the queue, reducing its elements transformed by xf like Transduce.
+// An element is popped only once the transformation has taken it: when it stops early,
+// e.g. with Take or TakeWhile, the element it rejected and the rest stay in the queue.
+// The elements a transformation like PartitionAll holds and then rejects with RejectHeld
+// are given back to the front of the queue, in order.
+// The queue must not be used concurrently meanwhile.
+func TransduceQueue[T, U, A any](xf Transducer[T, U], reducer func(A, U) A, init A, q queue.Queue[T]) A {
+	sink := xf(reducing(&init, reducer))
+	var held []T
+	for !q.Empty() {
+		v, e := q.Front()
+		if e != nil {
+			break
+		}
+		step := sink.Push(v)
+		if step == Reject || step == RejectHeld {
+			if step == RejectHeld {
+				giveBack(q, held)
+			}
+			break
+		}
+		_, _ = q.Pop()
+		if step == Hold {
+			held = append(held, v)
+			continue
+		}
+		held = held[:0]
+		if step != Continue {
+			break
+		}
+	}
+	sink.Complete()
+	return init
+}
+
+// giveBack puts the popped elements back to the front of the queue,
+// by pushing them and rotating the elements that were in the queue behind them.
+// They were in the queue before, so there is room for them.
+func giveBack[T any](q queue.Queue[T], ts []T) {
+	n := q.Size()
+	for _, t := range ts {
+		_ = q.Push(t)
+	}
+	for range n {
+		t, e := q.Pop()
+		if e != nil {
+			return
+		}
+		_ = q.Push(t)
+	}
+}
+
+// TransduceChan reduces the values received from the channel transformed by xf like Transduce,
+// until the channel is closed, the transformation stops, or the context is done.
+// In the last case, it returns the value reduced so far and the error of the context.
+// A value the transformation rejects is dropped, as are the values it rejects with RejectHeld,
+// as the channel cannot take them back.
+func TransduceChan[T, U, A any](ctx context.Context, xf Transducer[T, U], reducer func(A, U) A, init A, ch <-chan T) (A, error) {
+	sink := xf(reducing(&init, reducer))
+	for {
+		select {
+		case <-ctx.Done():
+			sink.Complete()
+			return init, ctx.Err()
+		case v, ok := <-ch:
+			if !ok || !sink.Push(v).more() {
+				sink.Complete()
+				return init, nil
+			}
+		}
+	}
+}
+
+// Chan returns a channel of the values received from in transformed by xf.
+// It is closed when in is closed, the transformation stops, or the context is done.
+//
+//	lines := transducer.Chan(ctx, transducer.Compose(transducer.Filter(nonEmpty), transducer.PartitionAll[string](100)), in)
+func Chan[T, U any](ctx context.Context, xf Transducer[T, U], in <-chan T) <-chan U {
+	out := make(chan U)
+	go func() {
+		defer close(out)
+		sink := xf(NewSink(func(v U) Step {
+			select {
+			case out <- v:
+				return Continue
+			case <-ctx.Done():
+				return Reject
+			}
+		}, nil))
+		defer sink.Complete()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok || !sink.Push(v).more() {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
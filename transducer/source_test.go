@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package transducer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mizumoto-cn/fpkit/queue"
+	"github.com/mizumoto-cn/fpkit/transducer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// xf is the transformation applied to every source below.
+var xf = transducer.Compose(transducer.Filter(isEven), transducer.Compose(transducer.Map(double), transducer.PartitionAll[int](2)))
+
+func sum(acc int, batch []int) int {
+	for _, v := range batch {
+		acc += v
+	}
+	return acc
+}
+
+func TestTransduce(t *testing.T) {
+	assert.Equal(t, 24, transducer.Transduce(xf, sum, 0, []int{1, 2, 3, 4, 5, 6}))
+	assert.Equal(t, 10, transducer.Transduce(xf, sum, 10, nil))
+	assert.Equal(t, []string{"a", "b"}, transducer.Into([]string{"a"}, transducer.Identity[string](), []string{"b"}))
+}
+
+func TestTransduceQueue(t *testing.T) {
+	q := queue.NewBasicQueue[int](10)
+	for i := 1; i <= 6; i++ {
+		assert.NoError(t, q.Push(i))
+	}
+	assert.Equal(t, 24, transducer.TransduceQueue(xf, sum, 0, q))
+	assert.True(t, q.Empty())
+
+	// stopping early leaves the rest in the queue
+	for i := 1; i <= 6; i++ {
+		assert.NoError(t, q.Push(i))
+	}
+	firstTwo := transducer.TransduceQueue(transducer.Take[int](2), func(acc, x int) int { return acc + x }, 0, q)
+	assert.Equal(t, 3, firstTwo)
+	assert.Equal(t, []int{3, 4, 5, 6}, drain(q))
+
+	// the element a TakeWhile stops on is rejected, so it stays in the queue
+	for i := 1; i <= 6; i++ {
+		assert.NoError(t, q.Push(i))
+	}
+	small := transducer.TransduceQueue(transducer.TakeWhile(func(x int) bool { return x < 3 }), func(acc, x int) int { return acc + x }, 0, q)
+	assert.Equal(t, 3, small)
+	assert.Equal(t, []int{3, 4, 5, 6}, drain(q))
+
+	// as does the first element with Take(0), even behind a Map
+	for i := 1; i <= 3; i++ {
+		assert.NoError(t, q.Push(i))
+	}
+	none := transducer.TransduceQueue(transducer.Compose(transducer.Map(double), transducer.Take[int](0)), func(acc, x int) int { return acc + x }, 0, q)
+	assert.Zero(t, none)
+	assert.Equal(t, []int{1, 2, 3}, drain(q))
+
+	// the elements of a batch the sink rejects stay in the queue
+	for i := 1; i <= 6; i++ {
+		assert.NoError(t, q.Push(i))
+	}
+	toBatches := func(acc [][]int, b []int) [][]int { return append(acc, b) }
+	batches := transducer.TransduceQueue(
+		transducer.Compose(transducer.PartitionAll[int](2), transducer.TakeWhile(func(b []int) bool { return b[0] < 3 })),
+		toBatches, nil, q)
+	assert.Equal(t, [][]int{{1, 2}}, batches)
+	assert.Equal(t, []int{3, 4, 5, 6}, drain(q))
+
+	// along with the elements filtered out in between
+	for i := 1; i <= 6; i++ {
+		assert.NoError(t, q.Push(i))
+	}
+	batches = transducer.TransduceQueue(
+		transducer.Compose(transducer.Filter(isEven), transducer.Compose(transducer.PartitionAll[int](2), transducer.Take[[]int](0))),
+		toBatches, nil, q)
+	assert.Empty(t, batches)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, drain(q))
+
+	// while a rejection before the batch leaves it to be flushed
+	for i := 1; i <= 6; i++ {
+		assert.NoError(t, q.Push(i))
+	}
+	batches = transducer.TransduceQueue(
+		transducer.Compose(transducer.TakeWhile(func(x int) bool { return x < 4 }), transducer.PartitionAll[int](2)),
+		toBatches, nil, q)
+	assert.Equal(t, [][]int{{1, 2}, {3}}, batches)
+	assert.Equal(t, []int{4, 5, 6}, drain(q))
+}
+
+// drain pops every element of the queue.
+func drain[T any](q queue.Queue[T]) []T {
+	var out []T
+	for !q.Empty() {
+		v, _ := q.Pop()
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestTransduceChan(t *testing.T) {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 1; i <= 6; i++ {
+			ch <- i
+		}
+	}()
+	got, e := transducer.TransduceChan(context.Background(), xf, sum, 0, ch)
+	assert.NoError(t, e)
+	assert.Equal(t, 24, got)
+
+	// the partial batch is flushed when the context ends
+	ch = make(chan int, 1)
+	ch <- 2
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	got, e = transducer.TransduceChan(ctx, xf, sum, 0, ch)
+	assert.ErrorIs(t, e, context.DeadlineExceeded)
+	assert.Equal(t, 4, got)
+}
+
+func TestChan(t *testing.T) {
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for i := 1; i <= 7; i++ {
+			in <- i
+		}
+	}()
+	var got [][]int
+	for batch := range transducer.Chan(context.Background(), xf, in) {
+		got = append(got, batch)
+	}
+	assert.Equal(t, [][]int{{4, 8}, {12}}, got)
+
+	// cancelling closes the output
+	ctx, cancel := context.WithCancel(context.Background())
+	out := transducer.Chan(ctx, transducer.Identity[int](), make(chan int))
+	cancel()
+	_, ok := <-out
+	assert.False(t, ok)
+
+	// stopping the transformation closes the output without draining the input
+	in = make(chan int, 10)
+	for i := 0; i < 10; i++ {
+		in <- i
+	}
+	var firsts []int
+	for v := range transducer.Chan(context.Background(), transducer.Take[int](3), in) {
+		firsts = append(firsts, v)
+	}
+	assert.Equal(t, []int{0, 1, 2}, firsts)
+	assert.Len(t, in, 7)
+}
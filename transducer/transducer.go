@@ -0,0 +1,261 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package transducer provides transducers: composable transformations of reducing steps,
+// independent of where the values come from and where they go.
+//
+// A transformation is defined once, and applied to a slice, a queue or a channel alike:
+//
+//	xf := transducer.Compose(transducer.Filter(isValid), transducer.Map(parse))
+//	records := transducer.Into(nil, xf, lines)                  // a slice
+//	records, err = transducer.TransduceChan(ctx, xf, appendFn, nil, ch) // a channel
+//
+// Transducers of the same type compose with functional.Compose as well,
+// the values flowing through the leftmost transducer first.
+package transducer
+
+// Step is what a Sink answers to a pushed value.
+type Step uint8
+
+const (
+	// Continue means the value is taken, and the sink wants more.
+	Continue Step = iota
+	// Stop means the value is taken, and the sink wants no more.
+	Stop
+	// Reject means the value is not taken, and the sink wants no more,
+	// so a source able to keep it, like a queue, does not remove it.
+	Reject
+	// Hold means the value is taken but has not reached the end of the transformation yet,
+	// e.g. it is buffered or filtered out, and the sink wants more.
+	Hold
+	// RejectHeld is Reject for the values held since the last one taken as well:
+	// the transformation has dropped them, so a source able to keep them gives them back.
+	RejectHeld
+)
+
+// more reports whether the sink wants more values after answering s.
+func (s Step) more() bool {
+	return s == Continue || s == Hold
+}
+
+// Sink receives the values of a transformation, one at a time.
+// Once Push returns Stop or Reject, the sink must not be pushed again.
+// Complete is called once at the end, e.g. to flush a partial batch,
+// after which the sink must not be pushed again either.
+type Sink[T any] interface {
+	Push(T) Step
+	Complete()
+}
+
+// sinkFuncs is a Sink made of functions.
+type sinkFuncs[T any] struct {
+	push     func(T) Step
+	complete func()
+}
+
+func (s sinkFuncs[T]) Push(v T) Step {
+	return s.push(v)
+}
+
+func (s sinkFuncs[T]) Complete() {
+	if s.complete != nil {
+		s.complete()
+	}
+}
+
+// NewSink creates a Sink from a push function and a completion function, which may be nil.
+func NewSink[T any](push func(T) Step, complete func()) Sink[T] {
+	return sinkFuncs[T]{push: push, complete: complete}
+}
+
+// Transducer transforms a sink of U values into a sink of T values.
+// It is called once per run, so stateful transducers like Take keep their state per run.
+type Transducer[T, U any] func(Sink[U]) Sink[T]
+
+// Compose returns the transducer passing the values through xf1, then through xf2.
+//
+//	Compose(Filter(isEven), Map(strconv.Itoa)) // Transducer[int, string]
+func Compose[A, B, C any](xf1 Transducer[A, B], xf2 Transducer[B, C]) Transducer[A, C] {
+	return func(s Sink[C]) Sink[A] {
+		return xf1(xf2(s))
+	}
+}
+
+// Identity returns the transducer passing the values unchanged.
+func Identity[T any]() Transducer[T, T] {
+	return func(s Sink[T]) Sink[T] {
+		return s
+	}
+}
+
+// Map returns the transducer applying fn to each value.
+func Map[T, U any](fn func(T) U) Transducer[T, U] {
+	return func(s Sink[U]) Sink[T] {
+		return NewSink(func(v T) Step {
+			return s.Push(fn(v))
+		}, s.Complete)
+	}
+}
+
+// Filter returns the transducer passing only the values satisfying the predicate.
+// The values it drops are held.
+func Filter[T any](pred func(T) bool) Transducer[T, T] {
+	return func(s Sink[T]) Sink[T] {
+		return NewSink(func(v T) Step {
+			if !pred(v) {
+				return Hold
+			}
+			return s.Push(v)
+		}, s.Complete)
+	}
+}
+
+// Remove returns the transducer dropping the values satisfying the predicate.
+func Remove[T any](pred func(T) bool) Transducer[T, T] {
+	return Filter(func(v T) bool { return !pred(v) })
+}
+
+// Take returns the transducer passing the first n values, then stopping the input.
+// It stops on the n-th value, so Take(0) rejects the first one.
+func Take[T any](n int) Transducer[T, T] {
+	return func(s Sink[T]) Sink[T] {
+		left := n
+		return NewSink(func(v T) Step {
+			if left <= 0 {
+				return Reject
+			}
+			left--
+			step := s.Push(v)
+			if step.more() && left == 0 {
+				return Stop
+			}
+			return step
+		}, s.Complete)
+	}
+}
+
+// TakeWhile returns the transducer passing the values while they satisfy the predicate,
+// then stopping the input. The first value failing the predicate is rejected.
+func TakeWhile[T any](pred func(T) bool) Transducer[T, T] {
+	return func(s Sink[T]) Sink[T] {
+		return NewSink(func(v T) Step {
+			if !pred(v) {
+				return Reject
+			}
+			return s.Push(v)
+		}, s.Complete)
+	}
+}
+
+// Drop returns the transducer dropping the first n values, which it holds.
+func Drop[T any](n int) Transducer[T, T] {
+	return func(s Sink[T]) Sink[T] {
+		left := n
+		return NewSink(func(v T) Step {
+			if left > 0 {
+				left--
+				return Hold
+			}
+			return s.Push(v)
+		}, s.Complete)
+	}
+}
+
+// Dedupe returns the transducer dropping the values equal to the previous one, which it holds.
+func Dedupe[T comparable]() Transducer[T, T] {
+	return func(s Sink[T]) Sink[T] {
+		var prev T
+		started := false
+		return NewSink(func(v T) Step {
+			if started && v == prev {
+				return Hold
+			}
+			prev, started = v, true
+			return s.Push(v)
+		}, s.Complete)
+	}
+}
+
+// PartitionAll returns the transducer grouping the values in slices of size values,
+// the last one holding the remaining values. It panics if size is not positive.
+// It holds the values of a batch until the batch is full,
+// and when the sink rejects the batch, it rejects them all with RejectHeld.
+func PartitionAll[T any](size int) Transducer[T, []T] {
+	if size <= 0 {
+		panic("transducer: PartitionAll with a non-positive size")
+	}
+	return func(s Sink[[]T]) Sink[T] {
+		var batch []T
+		stopped := false
+		return NewSink(func(v T) Step {
+			batch = append(batch, v)
+			if len(batch) < size {
+				return Hold
+			}
+			full := batch
+			batch = nil
+			step := s.Push(full)
+			switch step {
+			case Reject:
+				// the values of the batch are given back with v
+				stopped = true
+				return RejectHeld
+			case Stop, RejectHeld:
+				stopped = true
+			}
+			return step
+		}, func() {
+			if len(batch) > 0 && !stopped {
+				s.Push(batch)
+				batch = nil
+			}
+			s.Complete()
+		})
+	}
+}
+
+// Cat returns the transducer passing the elements of each slice.
+// A slice is held until one of its elements is taken.
+func Cat[T any]() Transducer[[]T, T] {
+	return func(s Sink[T]) Sink[[]T] {
+		return NewSink(func(vs []T) Step {
+			step := Hold
+			for i, v := range vs {
+				switch sub := s.Push(v); {
+				case sub == Continue:
+					step = Continue
+				case sub == Hold:
+				case sub == Reject && i == 0:
+					return Reject
+				case sub == RejectHeld && step == Hold:
+					// none of the slice is taken
+					return RejectHeld
+				default:
+					// part of the slice is taken
+					return Stop
+				}
+			}
+			return step
+		}, s.Complete)
+	}
+}
+
+// MapCat returns the transducer applying fn to each value, and passing the elements of the results.
+func MapCat[T, U any](fn func(T) []U) Transducer[T, U] {
+	return Compose(Map(fn), Cat[U]())
+}
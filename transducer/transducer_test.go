@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2024 Ruiyuan "mizumoto-cn" Xu
+ *
+ * This file is part of "github.com/mizumoto-cn/fpkit".
+ *
+ * Licensed under the Mizumoto General Public License v1.5 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/LICENSE
+ *     https://github.com/mizumoto-cn/fpkit/blob/main/licensing
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package transducer_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/mizumoto-cn/fpkit/functional"
+	"github.com/mizumoto-cn/fpkit/transducer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func isEven(x int) bool { return x%2 == 0 }
+
+func double(x int) int { return x * 2 }
+
+func TestMapFilter(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6}
+	assert.Equal(t, []int{2, 4, 6, 8, 10, 12}, transducer.Into(nil, transducer.Map(double), s))
+	assert.Equal(t, []int{2, 4, 6}, transducer.Into(nil, transducer.Filter(isEven), s))
+	assert.Equal(t, []int{1, 3, 5}, transducer.Into(nil, transducer.Remove(isEven), s))
+	assert.Equal(t, s, transducer.Into(nil, transducer.Identity[int](), s))
+	assert.Equal(t, []string{"2", "4", "6"},
+		transducer.Into(nil, transducer.Compose(transducer.Filter(isEven), transducer.Map(strconv.Itoa)), s))
+}
+
+func TestTakeDrop(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	assert.Equal(t, []int{1, 2}, transducer.Into(nil, transducer.Take[int](2), s))
+	assert.Equal(t, []int(nil), transducer.Into(nil, transducer.Take[int](0), s))
+	assert.Equal(t, s, transducer.Into(nil, transducer.Take[int](10), s))
+	assert.Equal(t, []int{4, 5}, transducer.Into(nil, transducer.Drop[int](3), s))
+	assert.Equal(t, []int{1, 2}, transducer.Into(nil, transducer.TakeWhile(func(x int) bool { return x < 3 }), s))
+
+	// Take stops the input: the mapping is not called on the rest
+	calls := 0
+	counted := transducer.Map(func(x int) int { calls++; return x })
+	transducer.Into(nil, transducer.Compose(counted, transducer.Take[int](2)), s)
+	assert.Equal(t, 2, calls)
+
+	// each run has its own state
+	take2 := transducer.Take[int](2)
+	assert.Equal(t, []int{1, 2}, transducer.Into(nil, take2, s))
+	assert.Equal(t, []int{1, 2}, transducer.Into(nil, take2, s))
+}
+
+func TestDedupe(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 1, 3}, transducer.Into(nil, transducer.Dedupe[int](), []int{1, 1, 2, 2, 2, 1, 3, 3}))
+	assert.Equal(t, []int{0}, transducer.Into(nil, transducer.Dedupe[int](), []int{0, 0}))
+}
+
+func TestPartitionAllCat(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, transducer.Into(nil, transducer.PartitionAll[int](2), s))
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, transducer.Into(nil, transducer.PartitionAll[int](2), s[:4]))
+	assert.Panics(t, func() { transducer.PartitionAll[int](0) })
+
+	// stopping downstream does not flush the partial batch
+	xf := transducer.Compose(transducer.PartitionAll[int](2), transducer.Take[[]int](1))
+	assert.Equal(t, [][]int{{1, 2}}, transducer.Into(nil, xf, s))
+
+	assert.Equal(t, []int{1, 2, 3}, transducer.Into(nil, transducer.Cat[int](), [][]int{{1}, {}, {2, 3}}))
+	roundTrip := transducer.Compose(transducer.PartitionAll[int](2), transducer.Cat[int]())
+	assert.Equal(t, s, transducer.Into(nil, roundTrip, s))
+	assert.Equal(t, []int{1, 1, 2, 2},
+		transducer.Into(nil, transducer.MapCat(func(x int) []int { return []int{x, x} }), []int{1, 2}))
+	assert.Equal(t, []int{1, 1, 2},
+		transducer.Into(nil, transducer.Compose(transducer.MapCat(func(x int) []int { return []int{x, x} }), transducer.Take[int](3)), []int{1, 2}))
+}
+
+func TestFunctionalCompose(t *testing.T) {
+	// same-type transducers compose with functional.Compose, left to right
+	xf := functional.Compose(transducer.Filter(isEven), transducer.Map(double), transducer.Take[int](2))
+	assert.Equal(t, []int{4, 8}, transducer.Into(nil, xf, []int{1, 2, 3, 4, 5, 6}))
+}
+
+func TestCustomTransducer(t *testing.T) {
+	// running sums
+	scan := func(s transducer.Sink[int]) transducer.Sink[int] {
+		sum := 0
+		return transducer.NewSink(func(v int) transducer.Step {
+			sum += v
+			return s.Push(sum)
+		}, s.Complete)
+	}
+	assert.Equal(t, []int{1, 3, 6}, transducer.Into(nil, scan, []int{1, 2, 3}))
+}
+
+func TestSteps(t *testing.T) {
+	discard := transducer.NewSink(func(int) transducer.Step { return transducer.Continue }, nil)
+
+	take := transducer.Take[int](2)(discard)
+	assert.Equal(t, transducer.Continue, take.Push(1))
+	assert.Equal(t, transducer.Stop, take.Push(2))
+	assert.Equal(t, transducer.Reject, transducer.Take[int](0)(discard).Push(1))
+
+	while := transducer.TakeWhile(isEven)(discard)
+	assert.Equal(t, transducer.Continue, while.Push(2))
+	assert.Equal(t, transducer.Reject, while.Push(3))
+
+	// a slice partly taken is taken
+	cat := transducer.Cat[int]()(transducer.Take[int](1)(discard))
+	assert.Equal(t, transducer.Stop, cat.Push([]int{1, 2}))
+	cat = transducer.Cat[int]()(transducer.Take[int](0)(discard))
+	assert.Equal(t, transducer.Reject, cat.Push([]int{1, 2}))
+
+	// the values not passed on yet are held, and given back with a rejected batch
+	assert.Equal(t, transducer.Hold, transducer.Filter(isEven)(discard).Push(1))
+	batch := transducer.PartitionAll[int](2)(transducer.Take[[]int](0)(transducer.NewSink(func([]int) transducer.Step { return transducer.Continue }, nil)))
+	assert.Equal(t, transducer.Hold, batch.Push(1))
+	assert.Equal(t, transducer.RejectHeld, batch.Push(2))
+	cat = transducer.Cat[int]()(transducer.PartitionAll[int](3)(transducer.Take[[]int](0)(transducer.NewSink(func([]int) transducer.Step { return transducer.Continue }, nil))))
+	assert.Equal(t, transducer.Hold, cat.Push([]int{1, 2}))
+	assert.Equal(t, transducer.RejectHeld, cat.Push([]int{3}))
+}